@@ -3,42 +3,282 @@ package dsky
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
+
+	"golang.org/x/term"
 )
 
 type Asker interface {
 	StringVar(string, string, bool) string
+
+	// StringVarE asks for a string using the question options and returns an error
+	// when the retries are exhausted or the input is closed
+	StringVarE(str string, question string, required bool, opts ...QuestionOption) (string, error)
+
+	// Confirm asks a yes/no question and returns def when the answer is empty
+	Confirm(question string, def bool) bool
+
+	// Select asks to pick one of the options, by number or value, and returns def when the answer is empty
+	Select(question string, options []string, def string) string
+
+	// MultiSelect asks to pick any of the options as a comma separated list, and returns def when the answer is empty
+	MultiSelect(question string, options []string, def []string) []string
+
+	// Password asks for a secret without echoing the input
+	Password(str string, question string, required bool) string
+
+	// IntVar asks for an integer, validated using validate when not nil, and returns val when the answer is empty
+	IntVar(val int, question string, validate func(int) error) int
 }
 
 type asker struct {
 	targetMode  ModeType
 	currentMode ModeType
+	in          io.Reader
+	out         io.Writer
+	reader      *bufio.Reader
+	log         Logger
 }
 
+// NewInteractiveAsker returns an asker that reads from stdin and prompts on stderr
 func NewInteractiveAsker(currentMode ModeType) Asker {
-	return &asker{targetMode: ModeTypeInteractive, currentMode: currentMode}
+	return NewAsker(currentMode, os.Stdin, os.Stderr)
+}
+
+// NewAsker returns an asker that reads the answers from in and writes the prompts to out.
+// Questions are only asked in interactive mode, the defaults are returned otherwise
+func NewAsker(currentMode ModeType, in io.Reader, out io.Writer) Asker {
+	if in == nil {
+		in = os.Stdin
+	}
+	if out == nil {
+		out = os.Stderr
+	}
+	return &asker{targetMode: ModeTypeInteractive, currentMode: currentMode, in: in, out: out}
 }
 
 func (a *asker) StringVar(str string, question string, required bool) string {
-	return AskStringVar(a.currentMode, a.targetMode, str, question, required)
+	str, _ = a.StringVarE(str, question, required)
+	return str
+}
 
+func (a *asker) StringVarE(str string, question string, required bool, opts ...QuestionOption) (string, error) {
+	if len(str) > 0 {
+		return str, nil
+	}
+	q := newQuestion(question, opts)
+	if a.currentMode != a.targetMode {
+		return q.def, nil
+	}
+	for attempt := 1; ; attempt++ {
+		res, err := a.readLine(q.prompt())
+		if err != nil {
+			return "", ErrInputClosed{Question: question}
+		}
+		if len(res) == 0 {
+			res = q.def
+		}
+		switch {
+		case len(res) == 0 && !required:
+			return "", nil
+		case len(res) == 0:
+			err = ErrAnswerRequired{}
+		case q.validate != nil:
+			err = q.validate(res)
+		}
+		if err == nil {
+			return res, nil
+		}
+		if q.retries > 0 && attempt >= q.retries {
+			return "", ErrTooManyRetries{Question: question, Retries: q.retries}
+		}
+		a.logger().Warn(err)
+	}
 }
 
-func AskStringVar(currentMode ModeType, targetMode ModeType, str string, question string, required bool) string {
-	if currentMode != targetMode {
+func (a *asker) logger() Logger {
+	if a.log == nil {
+		a.log = NewInteractiveLogger(a.out)
+	}
+	return a.log
+}
+
+func (a *asker) Confirm(question string, def bool) bool {
+	if a.currentMode != a.targetMode {
+		return def
+	}
+	hint := "[y/N]"
+	if def {
+		hint = "[Y/n]"
+	}
+	for {
+		res, err := a.readLine(fmt.Sprintf("%s %s ", question, hint))
+		if err != nil || len(res) == 0 {
+			return def
+		}
+		if ok, valid := parseConfirm(res); valid {
+			return ok
+		}
+	}
+}
+
+func (a *asker) Select(question string, options []string, def string) string {
+	if a.currentMode != a.targetMode || len(options) == 0 {
+		return def
+	}
+	for {
+		res, err := a.readLine(fmtOptions(question, options, def))
+		if err != nil || len(res) == 0 {
+			return def
+		}
+		if opt, ok := pickOption(options, res); ok {
+			return opt
+		}
+	}
+}
+
+func (a *asker) MultiSelect(question string, options []string, def []string) []string {
+	if a.currentMode != a.targetMode || len(options) == 0 {
+		return def
+	}
+outLoop:
+	for {
+		res, err := a.readLine(fmtOptions(question, options, strings.Join(def, ", ")))
+		if err != nil || len(res) == 0 {
+			return def
+		}
+		var picked []string
+		for _, r := range strings.Split(res, ",") {
+			opt, ok := pickOption(options, strings.TrimSpace(r))
+			if !ok {
+				continue outLoop
+			}
+			picked = append(picked, opt)
+		}
+		return picked
+	}
+}
+
+func (a *asker) Password(str string, question string, required bool) string {
+	if a.currentMode != a.targetMode {
 		return str
 	}
 	for len(str) == 0 {
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("\n", question)
-		res, _ := reader.ReadString('\n')
-		if res = strings.TrimSpace(res); res != "" {
-			str = res
+		res, err := a.readSecret(question)
+		if err != nil {
+			return str
+		}
+		str = res
+		if !required {
+			break
+		}
+	}
+	return str
+}
+
+func (a *asker) IntVar(val int, question string, validate func(int) error) int {
+	if a.currentMode != a.targetMode {
+		return val
+	}
+	for {
+		res, err := a.readLine(question)
+		if err != nil || len(res) == 0 {
+			return val
 		}
-		if required == true {
+		n, err := strconv.Atoi(res)
+		if err != nil {
+			fmt.Fprintf(a.out, "%q is not a number\n", res)
 			continue
 		}
+		if validate != nil {
+			if err := validate(n); err != nil {
+				fmt.Fprintln(a.out, err)
+				continue
+			}
+		}
+		return n
+	}
+}
+
+func (a *asker) readLine(question string) (string, error) {
+	if a.reader == nil {
+		a.reader = bufio.NewReader(a.in)
+	}
+	fmt.Fprint(a.out, "\n", question)
+	res, err := a.reader.ReadString('\n')
+	if err != nil && len(res) == 0 {
+		return "", err
+	}
+	return strings.TrimSpace(res), nil
+}
+
+// readSecret reads a line without echoing it when the input is a terminal
+func (a *asker) readSecret(question string) (string, error) {
+	f, ok := a.in.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return a.readLine(question)
 	}
+	fmt.Fprint(a.out, "\n", question)
+	b, err := term.ReadPassword(int(f.Fd()))
+	fmt.Fprintln(a.out)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// parseConfirm returns the answer to a yes/no question and whether the answer is valid
+func parseConfirm(answer string) (bool, bool) {
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, true
+	case "n", "no":
+		return false, true
+	}
+	return false, false
+}
+
+// fmtOptions returns the question followed by the numbered options
+func fmtOptions(question string, options []string, def string) string {
+	var buf strings.Builder
+	buf.WriteString(question)
+	buf.WriteString("\n")
+	for idx, opt := range options {
+		buf.WriteString(fmt.Sprintf("  %d) %s\n", idx+1, opt))
+	}
+	if len(def) > 0 {
+		buf.WriteString(fmt.Sprintf("[%s] ", def))
+	}
+	return buf.String()
+}
+
+// pickOption returns the option matching the answer, either by its number or value
+func pickOption(options []string, answer string) (string, bool) {
+	if n, err := strconv.Atoi(answer); err == nil {
+		if n > 0 && n <= len(options) {
+			return options[n-1], true
+		}
+		return "", false
+	}
+	for _, opt := range options {
+		if strings.EqualFold(opt, answer) {
+			return opt, true
+		}
+	}
+	return "", false
+}
+
+func AskStringVar(currentMode ModeType, targetMode ModeType, str string, question string, required bool) string {
+	str, _ = AskStringVarE(currentMode, targetMode, str, question, required)
 	return str
 }
+
+// AskStringVarE is AskStringVar with question options, it returns an error
+// when the retries are exhausted or stdin is closed. The prompts are written to stderr
+func AskStringVarE(currentMode ModeType, targetMode ModeType, str string, question string, required bool, opts ...QuestionOption) (string, error) {
+	a := &asker{targetMode: targetMode, currentMode: currentMode, in: os.Stdin, out: os.Stderr}
+	return a.StringVarE(str, question, required, opts...)
+}
@@ -0,0 +1,162 @@
+package dsky
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAsker_NonInteractiveDefaults(t *testing.T) {
+	a := NewInteractiveAsker(ModeTypeJSON)
+	if got := a.Confirm("continue?", true); got != true {
+		t.Errorf("expected true, actual %v", got)
+	}
+	if got := a.Select("region?", []string{"west", "east"}, "east"); got != "east" {
+		t.Errorf("expected east, actual %q", got)
+	}
+	if got := a.MultiSelect("regions?", []string{"west", "east"}, []string{"west"}); !reflect.DeepEqual(got, []string{"west"}) {
+		t.Errorf("expected [west], actual %v", got)
+	}
+	if got := a.Password("secret", "password: ", true); got != "secret" {
+		t.Errorf("expected secret, actual %q", got)
+	}
+	if got := a.IntVar(3, "count: ", nil); got != 3 {
+		t.Errorf("expected 3, actual %d", got)
+	}
+}
+
+func TestPickOption(t *testing.T) {
+	options := []string{"west", "east"}
+	cases := []struct {
+		answer string
+		want   string
+		ok     bool
+	}{
+		{"1", "west", true},
+		{"EAST", "east", true},
+		{"3", "", false},
+		{"north", "", false},
+	}
+	for _, c := range cases {
+		got, ok := pickOption(options, c.answer)
+		if got != c.want || ok != c.ok {
+			t.Errorf("%q: expected (%q, %v), actual (%q, %v)", c.answer, c.want, c.ok, got, ok)
+		}
+	}
+}
+
+func TestAsker_Reader(t *testing.T) {
+	var out bytes.Buffer
+	in := strings.NewReader("maybe\ny\n2\nabc\n7\n")
+	m, err := NewMode(ModeTypeInteractive, WithStderr(&out), WithStdin(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := m.Ask()
+	if got := a.Confirm("continue?", false); got != true {
+		t.Errorf("expected true, actual %v", got)
+	}
+	if got := a.Select("region?", []string{"west", "east"}, ""); got != "east" {
+		t.Errorf("expected east, actual %q", got)
+	}
+	if got := a.IntVar(0, "count: ", nil); got != 7 {
+		t.Errorf("expected 7, actual %d", got)
+	}
+	if !strings.Contains(out.String(), "continue? [y/N]") {
+		t.Errorf("expected prompts on errout, actual %q", out.String())
+	}
+}
+
+func TestScriptedAsker(t *testing.T) {
+	var errout bytes.Buffer
+	a := NewScriptedAsker(map[string]string{"name:": "web", "continue?": "yes", "region?": ""})
+	m, err := NewMode(ModeTypeShell, WithStderr(&errout), WithAsker(a))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ask := m.Ask()
+	if got := ask.StringVar("", "name: ", true); got != "web" {
+		t.Errorf("expected web, actual %q", got)
+	}
+	if got := ask.Confirm("continue?", false); got != true {
+		t.Errorf("expected true, actual %v", got)
+	}
+	if got := ask.Select("region?", []string{"west", "east"}, "east"); got != "east" {
+		t.Errorf("expected the default for an empty answer, actual %q", got)
+	}
+	if err := a.Err(); err != nil || errout.Len() > 0 {
+		t.Fatalf("expected no error, actual %v: %q", err, errout.String())
+	}
+
+	if got := ask.Confirm("deploy?", true); got != true {
+		t.Errorf("expected the default, actual %v", got)
+	}
+	if _, ok := a.Err().(ErrUnansweredQuestion); !ok {
+		t.Errorf("expected ErrUnansweredQuestion, actual %v", a.Err())
+	}
+	if !strings.Contains(errout.String(), `no scripted answer for question "deploy?"`) {
+		t.Errorf("expected the unanswered question to be logged, actual %q", errout.String())
+	}
+	if _, err := a.StringVarE("", "deploy?", false, WithDefaultAnswer("yes")); err == nil {
+		t.Error("expected error for unanswered question with a default")
+	}
+}
+
+func TestScriptedAsker_Required(t *testing.T) {
+	var errout bytes.Buffer
+	a := NewScriptedAsker(map[string]string{"name:": ""}).WithLogger(NewShellLogger(&errout))
+	if got := a.StringVar("", "name:", false); got != "" {
+		t.Errorf("expected empty answer, actual %q", got)
+	}
+	if err := a.Err(); err != nil {
+		t.Fatalf("expected no error for optional question, actual %v", err)
+	}
+	a.StringVar("", "name:", true)
+	if _, ok := a.Err().(ErrUnansweredQuestion); !ok {
+		t.Errorf("expected ErrUnansweredQuestion for required question, actual %v", a.Err())
+	}
+	if !strings.Contains(errout.String(), "name:") {
+		t.Errorf("expected the required question to be logged, actual %q", errout.String())
+	}
+}
+
+func TestAsker_StringVarE(t *testing.T) {
+	var out bytes.Buffer
+	a := NewAsker(ModeTypeInteractive, strings.NewReader("\nab\nabcd\n"), &out)
+	notShort := func(s string) error {
+		if len(s) < 4 {
+			return fmt.Errorf("%q is too short", s)
+		}
+		return nil
+	}
+	got, err := a.StringVarE("", "name: ", true, WithDefaultAnswer("x"), WithValidator(notShort))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "abcd" {
+		t.Errorf("expected abcd, actual %q", got)
+	}
+	if !strings.Contains(out.String(), "name [x]: ") {
+		t.Errorf("expected default in prompt, actual %q", out.String())
+	}
+	if !strings.Contains(out.String(), `"ab" is too short`) {
+		t.Errorf("expected validation error in output, actual %q", out.String())
+	}
+
+	a = NewAsker(ModeTypeInteractive, strings.NewReader("ab\nab\nabcd\n"), &out)
+	if _, err := a.StringVarE("", "name: ", true, WithValidator(notShort), WithMaxRetries(2)); err == nil {
+		t.Error("expected too many retries error")
+	}
+
+	a = NewAsker(ModeTypeInteractive, strings.NewReader(""), &out)
+	if _, err := a.StringVarE("", "name: ", true); err == nil {
+		t.Error("expected error on closed input")
+	}
+
+	a = NewAsker(ModeTypeJSON, strings.NewReader(""), &out)
+	if got, _ := a.StringVarE("", "name: ", true, WithDefaultAnswer("web")); got != "web" {
+		t.Errorf("expected default in non interactive mode, actual %q", got)
+	}
+}
@@ -1,21 +1,126 @@
 package dsky
 
 import (
+	"fmt"
+	"strings"
+
 	fc "github.com/fatih/color"
 )
 
-var Color = NewColor()
+// Style is a space separated list of the color attributes to render text with, i.e: "hi-green bold".
+// The colors are black, red, green, yellow, blue, magenta, cyan and white, with the hi- prefix for
+// the high intensity variants and the bg- prefix for the background. The other attributes are bold,
+// faint, italic, underline and reverse. The empty style renders the text as is.
+type Style string
+
+// ErrInvalidStyle is returned for a style with an unknown attribute
+type ErrInvalidStyle struct {
+	Style     Style
+	Attribute string
+}
+
+func (e ErrInvalidStyle) Error() string {
+	return fmt.Sprintf("dsky: invalid attribute %q in style %q", e.Attribute, e.Style)
+}
+
+var styleColors = map[string]fc.Attribute{
+	"black":   fc.FgBlack,
+	"red":     fc.FgRed,
+	"green":   fc.FgGreen,
+	"yellow":  fc.FgYellow,
+	"blue":    fc.FgBlue,
+	"magenta": fc.FgMagenta,
+	"cyan":    fc.FgCyan,
+	"white":   fc.FgWhite,
+}
+
+var styleModifiers = map[string]fc.Attribute{
+	"bold":      fc.Bold,
+	"faint":     fc.Faint,
+	"italic":    fc.Italic,
+	"underline": fc.Underline,
+	"reverse":   fc.ReverseVideo,
+}
+
+// attributes parses the style into the color attributes
+func (s Style) attributes() ([]fc.Attribute, error) {
+	var attrs []fc.Attribute
+	for _, name := range strings.Fields(strings.ToLower(string(s))) {
+		if a, ok := styleModifiers[name]; ok {
+			attrs = append(attrs, a)
+			continue
+		}
+		color, offset := name, fc.Attribute(0)
+		if strings.HasPrefix(color, "bg-") {
+			color, offset = strings.TrimPrefix(color, "bg-"), fc.BgBlack-fc.FgBlack
+		}
+		if strings.HasPrefix(color, "hi-") {
+			color, offset = strings.TrimPrefix(color, "hi-"), offset+fc.FgHiBlack-fc.FgBlack
+		}
+		a, ok := styleColors[color]
+		if !ok {
+			return nil, ErrInvalidStyle{Style: s, Attribute: name}
+		}
+		attrs = append(attrs, a+offset)
+	}
+	return attrs, nil
+}
+
+// palette is the set of colors a theme renders with
+type palette struct {
+	labels   map[logModeType]*fc.Color
+	messages map[logModeType]*fc.Color
+	actions  map[LogAction]*fc.Color
+	headings []*fc.Color
+	Dim      *fc.Color
+	Accent   *fc.Color
+}
 
-type color struct {
-	Success, Notice, Failure, Hi, Normal *fc.Color
+// newPalette returns the colors of the theme, enabled or disabled regardless
+// of whether the standard output is a terminal. Invalid styles render as is
+func newPalette(t *Theme, enabled bool) *palette {
+	color := func(s Style) *fc.Color {
+		attrs, _ := s.attributes()
+		c := fc.New(attrs...)
+		if enabled && len(attrs) > 0 {
+			c.EnableColor()
+		} else {
+			c.DisableColor()
+		}
+		return c
+	}
+	p := &palette{
+		labels:   make(map[logModeType]*fc.Color),
+		messages: make(map[logModeType]*fc.Color),
+		actions:  make(map[LogAction]*fc.Color),
+		Dim:      color(t.Dim),
+		Accent:   color(t.Accent),
+	}
+	for _, level := range []logModeType{logModeTypeDebug, logModeTypeInfo, logModeTypeWarn, logModeTypeError} {
+		ls := t.Levels[string(level)]
+		p.labels[level], p.messages[level] = color(ls.Label), color(ls.Message)
+	}
+	for action, s := range t.Actions {
+		p.actions[LogAction(action)] = color(s)
+	}
+	for _, h := range t.Headings {
+		p.headings = append(p.headings, color(h.Color))
+	}
+	return p
+}
+
+// label returns the color of the label of a log item, the action's when the theme sets one
+func (p *palette) label(level logModeType, action LogAction) *fc.Color {
+	if c, ok := p.actions[action]; ok {
+		return c
+	}
+	return p.labels[level]
 }
 
-func NewColor() *color {
-	return &color{
-		Success: fc.New(fc.FgHiGreen),
-		Notice:  fc.New(fc.FgHiYellow),
-		Failure: fc.New(fc.FgHiRed),
-		Hi:      fc.New(fc.FgHiWhite),
-		Normal:  fc.New(fc.FgWhite),
+// heading returns the color of the heading level, starting at 1
+func (p *palette) heading(level int) *fc.Color {
+	if level < 1 || level > len(p.headings) {
+		return nil
 	}
+	return p.headings[level-1]
 }
@@ -0,0 +1,209 @@
+package dsky
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CSVMode renders section data as comma (or tab) separated records with a header row.
+// Map and nested section data cells are flattened into dotted column names.
+type CSVMode struct {
+	sections []Section
+	comma    rune
+	common
+}
+
+func NewCSVMode(out, errout io.Writer) *CSVMode {
+	return newCSVMode(ModeTypeCSV, ',', out, errout)
+}
+
+func NewTSVMode(out, errout io.Writer) *CSVMode {
+	return newCSVMode(ModeTypeTSV, '\t', out, errout)
+}
+
+func newCSVMode(mtype ModeType, comma rune, out, errout io.Writer) *CSVMode {
+	if out == nil {
+		out = os.Stdout
+	}
+	if errout == nil {
+		errout = os.Stderr
+	}
+	m := &CSVMode{
+		sections: make([]Section, 0),
+		comma:    comma,
+	}
+	m.modeType = mtype
+	m.out = out
+	m.errout = errout
+	m.logger = NewJSONLogger(errout)
+	return m
+}
+
+func (m *CSVMode) When(mtype ModeType, fn runF) Mode {
+	if mtype == m.modeType {
+		m.mu.Lock()
+		m.runners = append(m.runners, fn)
+		m.mu.Unlock()
+	}
+	return m
+}
+
+func (i *CSVMode) Printer() Printer {
+	return i
+}
+
+func (i *CSVMode) NewSection(id string) Section {
+	s := NewSection(id)
+	i.mu.Lock()
+	i.sections = append(i.sections, s)
+	i.mu.Unlock()
+	return s
+}
+
+func (i *CSVMode) WithSection(s Section) Printer {
+	i.mu.Lock()
+	i.sections = append(i.sections, s)
+	i.mu.Unlock()
+	return i
+}
+
+// ErrDuplicateColumn is returned by CSVMode when two columns of a section have the
+// same header, ignoring the case and the spacing of the labels
+type ErrDuplicateColumn struct {
+	Section string
+	Column  string
+}
+
+func (e ErrDuplicateColumn) Error() string {
+	return fmt.Sprintf("dsky: duplicate column %q in section %q", e.Column, e.Section)
+}
+
+// Flush writes a table for each section, separated by an empty line
+func (i *CSVMode) Flush() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	var buf bytes.Buffer
+	for _, sec := range i.sections {
+		if sec == nil || sec.Data() == nil {
+			continue
+		}
+		b, err := sec.Data().Marshal(i)
+		if err != nil {
+			return err
+		}
+		if len(b) == 0 {
+			continue
+		}
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.Write(b)
+	}
+	_, err := fmt.Fprint(i.out, buf.String())
+	return err
+}
+
+// MarshalSectionData returns the rows of the section data as a table with a header line,
+// nothing when there are no rows
+func (i *CSVMode) MarshalSectionData(sectionData SectionData) ([]byte, error) {
+	var headers []string
+	seen := make(map[string]string) // the headers by their normalized name
+	var recs []map[string]string
+	for _, row := range sectionData.Rows() {
+		var cols []string
+		rec := make(map[string]string)
+		flattenRow(sectionData, row, "", &cols, rec)
+		if len(rec) < len(cols) {
+			return nil, ErrDuplicateColumn{Section: sectionData.Identifier(), Column: duplicate(cols)}
+		}
+		for _, c := range cols {
+			key := strings.ToLower(strings.Join(strings.Fields(c), ""))
+			h, ok := seen[key]
+			if ok && h != c {
+				return nil, ErrDuplicateColumn{Section: sectionData.Identifier(), Column: c}
+			}
+			if !ok {
+				seen[key] = c
+				headers = append(headers, c)
+			}
+		}
+		recs = append(recs, rec)
+	}
+	if len(recs) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = i.comma
+	if err := w.Write(headers); err != nil {
+		return nil, err
+	}
+	for _, rec := range recs {
+		line := make([]string, len(headers))
+		for idx, h := range headers {
+			line[idx] = rec[h]
+		}
+		if err := w.Write(line); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// duplicate returns the first column that appears twice
+func duplicate(cols []string) string {
+	seen := make(map[string]bool)
+	for _, c := range cols {
+		if seen[c] {
+			return c
+		}
+		seen[c] = true
+	}
+	return ""
+}
+
+// flattenRow adds the cells of the row to rec keyed by their dotted column name, and appends
+// the column names in the order they appear to cols
+func flattenRow(sectionData SectionData, row []interface{}, prefix string, cols *[]string, rec map[string]string) {
+	ids := sectionData.IDs()
+	for colidx, cell := range row {
+		name := ids[colidx]
+		if l := sectionData.Label(name); len(l) > 0 {
+			name = l
+		}
+		name = prefix + name
+		switch item := cell.(type) {
+		case nil:
+			// missing cell, the column is declared by the rows that have it
+		case map[string]string:
+			var keys []string
+			for k := range item {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				col := name + "." + k
+				*cols = append(*cols, col)
+				rec[col] = re.ReplaceAllString(item[k], "")
+			}
+		case SectionData:
+			for rowidx, r := range item.Rows() {
+				flattenRow(item, r, name+"."+strconv.Itoa(rowidx)+".", cols, rec)
+			}
+		default:
+			*cols = append(*cols, name)
+			rec[name] = re.ReplaceAllString(fmt.Sprintf("%v", item), "")
+		}
+	}
+}
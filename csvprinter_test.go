@@ -0,0 +1,70 @@
+package dsky
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCSVMode_Flush(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewCSVMode(&buf, nil)
+	d := m.NewSection("Groups").NewData().AsList()
+	d.Add("Seq", 1).WithLabel("Seq", "Sequence").
+		Add("Name", "west, coast").
+		Add("Requirements", map[string]string{"region": "us-west"}).
+		Add("Bids", NewSectionData("").Add("Price", "9"))
+	d.Add("Seq", 2).Add("Name", "east")
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "Sequence,Name,Requirements.region,Bids.0.Price\n" +
+		"1,\"west, coast\",us-west,9\n" +
+		"2,east,,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\nactual:\n%s", want, got)
+	}
+}
+
+func TestTSVMode_Flush(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewTSVMode(&buf, nil)
+	m.NewSection("Groups").NewData().Add("Seq", 1).Add("Name", "west")
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "Seq\tName\n1\twest\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
+
+func TestCSVMode_SectionWithoutData(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewCSVMode(&buf, nil)
+	m.NewSection("empty")
+	m.NewSection("no rows").NewData().AsList()
+	m.NewSection("deploy").NewData().AsPane().Add("id", "abc")
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "id\nabc\n"; buf.String() != want {
+		t.Errorf("expected %q, actual %q", want, buf.String())
+	}
+}
+
+func TestCSVMode_DuplicateColumn(t *testing.T) {
+	for _, labels := range [][2]string{{"Deploy ID", "deploy id"}, {"ID", "ID"}} {
+		var buf bytes.Buffer
+		m := NewCSVMode(&buf, nil)
+		m.NewSection("deploy").NewData().
+			Add("a", "abc").WithLabel("a", labels[0]).
+			Add("b", "def").WithLabel("b", labels[1])
+		err := m.Flush()
+		if _, ok := err.(ErrDuplicateColumn); !ok {
+			t.Errorf("%q: expected ErrDuplicateColumn, actual %v", labels, err)
+		}
+		if buf.Len() > 0 {
+			t.Errorf("%q: expected no output, actual %q", labels, buf.String())
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package dsky
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// ModeEnv is the environment variable overriding the detected mode, i.e: DSKY_MODE=json
+const ModeEnv = "DSKY_MODE"
+
+// DetectMode returns the mode type for the output: the explicit mode when set, i.e: from a flag,
+// the mode set using DSKY_MODE otherwise, and ModeTypeInteractive when stdout is a terminal or
+// ModeTypeJSON when it is not, i.e: piped to another program. A nil stdout is os.Stdout.
+func DetectMode(explicit ModeType, stdout io.Writer) (ModeType, error) {
+	if len(explicit) > 0 {
+		return explicit, validateModeType(explicit)
+	}
+	if v := strings.TrimSpace(os.Getenv(ModeEnv)); len(v) > 0 {
+		m := ModeType(strings.ToLower(v))
+		return m, validateModeType(m)
+	}
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if NewTerminal(stdout).IsTTY() {
+		return ModeTypeInteractive, nil
+	}
+	return ModeTypeJSON, nil
+}
+
+// String returns the name of the mode type
+func (m ModeType) String() string {
+	return string(m)
+}
+
+// Set sets the mode type, it returns ErrInvalidModeType for a mode that is not registered. Along with
+// String and Type, it makes *ModeType a flag.Value and a pflag.Value:
+//
+//	var mode dsky.ModeType
+//	flag.Var(&mode, "m", "output mode")
+//	flag.Parse()
+//	mt, err := dsky.DetectMode(mode, os.Stdout)
+func (m *ModeType) Set(s string) error {
+	mt := ModeType(strings.ToLower(strings.TrimSpace(s)))
+	if err := validateModeType(mt); err != nil {
+		return err
+	}
+	*m = mt
+	return nil
+}
+
+// Type returns the type name of the flag value for pflag
+func (m *ModeType) Type() string {
+	return "mode"
+}
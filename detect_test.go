@@ -0,0 +1,45 @@
+package dsky
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"testing"
+)
+
+func TestDetectMode(t *testing.T) {
+	var buf bytes.Buffer
+	t.Setenv(ModeEnv, "")
+	if m, err := DetectMode("", &buf); err != nil || m != ModeTypeJSON {
+		t.Errorf("expected json when not writing to a terminal, actual %q (%v)", m, err)
+	}
+
+	t.Setenv(ModeEnv, "YAML")
+	if m, err := DetectMode("", &buf); err != nil || m != ModeTypeYAML {
+		t.Errorf("expected the mode from the environment, actual %q (%v)", m, err)
+	}
+	if m, err := DetectMode(ModeTypeShell, &buf); err != nil || m != ModeTypeShell {
+		t.Errorf("expected the explicit mode, actual %q (%v)", m, err)
+	}
+
+	t.Setenv(ModeEnv, "fancy")
+	if _, err := DetectMode("", &buf); err == nil {
+		t.Error("expected an error for an invalid mode in the environment")
+	}
+}
+
+func TestModeType_Flag(t *testing.T) {
+	var mode ModeType
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Var(&mode, "m", "output mode")
+	if err := fs.Parse([]string{"-m", "NDJSON"}); err != nil {
+		t.Fatal(err)
+	}
+	if mode != ModeTypeNDJSON {
+		t.Errorf("expected %q, actual %q", ModeTypeNDJSON, mode)
+	}
+	if err := fs.Parse([]string{"-m", "fancy"}); err == nil {
+		t.Error("expected an error for an invalid mode")
+	}
+}
@@ -0,0 +1,170 @@
+package dsky
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ExitCodeOK is the exit code of a successful run
+	ExitCodeOK = 0
+	// ExitCodeError is the exit code of a failed run, unless the error sets its own
+	ExitCodeError = 1
+	// ExitCodeUsage is the exit code of a run that failed due to invalid usage, i.e: an unknown mode
+	ExitCodeUsage = 2
+)
+
+// Error is an error with a hint on how to resolve it and the exit code of the process.
+// The loggers render the hint along with the causes of the error.
+type Error struct {
+	Err  error
+	Hint string
+	Code int
+}
+
+// NewError returns an Error wrapping err
+func NewError(err error) *Error {
+	return &Error{Err: err}
+}
+
+// Errorf returns an Error with the message formatted using fmt.Errorf, use %w to wrap the cause
+func Errorf(format string, a ...interface{}) *Error {
+	return &Error{Err: fmt.Errorf(format, a...)}
+}
+
+// WithHint sets the suggestion shown along with the error
+func (e *Error) WithHint(hint string) *Error {
+	e.Hint = hint
+	return e
+}
+
+// WithCode sets the exit code of the process
+func (e *Error) WithCode(code int) *Error {
+	e.Code = code
+	return e
+}
+
+func (e *Error) Error() string {
+	if e == nil || e.Err == nil {
+		return "dsky: error"
+	}
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
+
+func (e *Error) ExitCode() int {
+	if e == nil || e.Code == 0 {
+		return ExitCodeError
+	}
+	return e.Code
+}
+
+// ExitCode returns the exit code of the process for the error returned by Mode.Run. It is
+// ExitCodeOK for nil, the code of the first error in the chain that has an ExitCode method
+// and ExitCodeError otherwise.
+//
+//	if err := mode.Run(); err != nil {
+//		mode.Printer().Log().Error(err)
+//		os.Exit(dsky.ExitCode(err))
+//	}
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+	var ec interface{ ExitCode() int }
+	if errors.As(err, &ec) {
+		return ec.ExitCode()
+	}
+	return ExitCodeError
+}
+
+// ErrorHint returns the hint of the first Error in the chain that has one
+func ErrorHint(err error) string {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if e, ok := err.(*Error); ok && e != nil && len(e.Hint) > 0 {
+			return e.Hint
+		}
+	}
+	return ""
+}
+
+// errorChain returns the messages of the error and of the errors it wraps. The message
+// of a cause is trimmed from the one of the error wrapping it, so that "deploy: timeout"
+// wrapping "timeout" reads as "deploy" caused by "timeout".
+func errorChain(err error) []string {
+	var chain []string
+	for err != nil {
+		msg := err.Error()
+		next := errors.Unwrap(err)
+		if next != nil {
+			cause := next.Error()
+			if cause == msg {
+				// the error only annotates its cause, i.e: an Error
+				err = next
+				continue
+			}
+			msg = strings.TrimSuffix(msg, cause)
+			msg = strings.TrimRight(strings.TrimSpace(msg), ":")
+		}
+		if len(msg) > 0 {
+			chain = append(chain, msg)
+		}
+		err = next
+	}
+	return chain
+}
+
+// errorReport is the presentation of an error logged by the loggers
+type errorReport struct {
+	Message string   `json:"message"`
+	Causes  []string `json:"causes,omitempty"`
+	Hint    string   `json:"hint,omitempty"`
+	Code    int      `json:"code"`
+}
+
+// presentErrors replaces the first error of the message with its own message and returns
+// the report of that error, or nil when the message has no errors
+func presentErrors(msg []interface{}) ([]interface{}, *errorReport) {
+	for idx, m := range msg {
+		err, ok := m.(error)
+		if !ok || err == nil {
+			continue
+		}
+		if e, ok := err.(*Error); ok && e == nil {
+			// a typed nil is not an error to report
+			continue
+		}
+		chain := errorChain(err)
+		if len(chain) == 0 {
+			chain = []string{err.Error()}
+		}
+		res := append([]interface{}{}, msg...)
+		res[idx] = chain[0]
+		return res, &errorReport{
+			Message: err.Error(),
+			Causes:  chain[1:],
+			Hint:    ErrorHint(err),
+			Code:    ExitCode(err),
+		}
+	}
+	return msg, nil
+}
+
+// lines returns the indented "caused by" lines followed by the hint
+func (r *errorReport) lines() []string {
+	var lines []string
+	for idx, c := range r.Causes {
+		lines = append(lines, fmt.Sprintf("%scaused by: %s", strings.Repeat("  ", idx+1), c))
+	}
+	if len(r.Hint) > 0 {
+		lines = append(lines, "hint: "+r.Hint)
+	}
+	return lines
+}
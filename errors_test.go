@@ -0,0 +1,91 @@
+package dsky
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrorChain(t *testing.T) {
+	root := errors.New("connection refused")
+	err := NewError(fmt.Errorf("deploy: %w", fmt.Errorf("dial provider: %w", root)))
+	want := []string{"deploy", "dial provider", "connection refused"}
+	if got := errorChain(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{nil, ExitCodeOK},
+		{errors.New("failed"), ExitCodeError},
+		{NewError(errors.New("failed")), ExitCodeError},
+		{fmt.Errorf("run: %w", NewError(errors.New("failed")).WithCode(3)), 3},
+		{ErrInvalidModeType{}, ExitCodeUsage},
+	}
+	for _, c := range cases {
+		if got := ExitCode(c.err); got != c.code {
+			t.Errorf("%v: expected %d, actual %d", c.err, c.code, got)
+		}
+	}
+}
+
+func TestPresentErrors_TypedNil(t *testing.T) {
+	var err *Error
+	msg, report := presentErrors([]interface{}{"failed", err})
+	if report != nil || len(msg) != 2 {
+		t.Errorf("expected no report for a typed nil, actual %v %+v", msg, report)
+	}
+	_, report = presentErrors([]interface{}{fmt.Errorf("run: %w", err)})
+	if report == nil || report.Code != ExitCodeError {
+		t.Errorf("expected a report for the wrapping error, actual %+v", report)
+	}
+	var buf bytes.Buffer
+	NewShellLogger(&buf).Error("failed", err)
+	if !strings.HasPrefix(buf.String(), "# error failed") {
+		t.Errorf("expected the message to be logged, actual %q", buf.String())
+	}
+}
+
+func TestInteractiveLogger_Error(t *testing.T) {
+	var buf bytes.Buffer
+	err := Errorf("deploy: %w", errors.New("timeout")).WithHint("check the provider")
+	NewInteractiveLogger(&buf).Error(err)
+	lines := strings.Split(strings.TrimSpace(re.ReplaceAllString(buf.String(), "")), "\n")
+	want := []string{"(error) deploy", "caused by: timeout", "hint: check the provider"}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, actual %q", len(want), lines)
+	}
+	for idx, w := range want {
+		if !strings.Contains(lines[idx], w) {
+			t.Errorf("line %d: expected %q, actual %q", idx, w, lines[idx])
+		}
+	}
+}
+
+func TestJSONLogger_Error(t *testing.T) {
+	var buf bytes.Buffer
+	err := Errorf("deploy: %w", errors.New("timeout")).WithHint("check the provider").WithCode(3)
+	NewJSONLogger(&buf).Error("failed to", err)
+	var got struct {
+		Message string
+		Error   errorReport
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := errorReport{Message: "deploy: timeout", Causes: []string{"timeout"}, Hint: "check the provider", Code: 3}
+	if !reflect.DeepEqual(got.Error, want) {
+		t.Errorf("expected %+v, actual %+v", want, got.Error)
+	}
+	if got.Message != "failed to deploy" {
+		t.Errorf("expected the message of the error only, actual %q", got.Message)
+	}
+}
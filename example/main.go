@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"os"
 
 	"github.com/ovrclk/dsky"
 )
@@ -16,9 +18,13 @@ type group struct {
 
 type bid struct{ group, price, provider string }
 
+var errNoProviders = errors.New("no providers available in us-east")
+
 var (
-	mode   string
-	groups = []group{
+	modeType  dsky.ModeType
+	theme     string
+	verbosity dsky.Verbosity
+	groups    = []group{
 		{1, "west", map[string]string{"region": "us-west"}, map[string]string{"cpu": "200", "memory": "2Gb", "price": "100", "count": "2"}},
 		{2, "east", map[string]string{"region": "us-east"}, map[string]string{"cpu": "800", "memory": "4Gb", "price": "80", "count": "5"}},
 	}
@@ -30,21 +36,34 @@ var (
 )
 
 func main() {
-	flag.StringVar(&mode, "m", string(dsky.ModeTypeInteractive), "mode")
+	flag.Var(&modeType, "m", "output mode, detected from DSKY_MODE and the terminal when not set")
+	flag.StringVar(&theme, "theme", dsky.DefaultTheme.Name, "theme of the interactive mode")
+	flag.Var(&verbosity, "v", "verbose output, repeat for more")
 	flag.Parse()
 
-	mode, err := dsky.NewMode(dsky.ModeType(mode), nil, nil)
-	printer := mode.Printer()
+	th, err := dsky.ThemeByName(theme)
+	if err != nil {
+		panic(err)
+	}
+
+	mt, err := dsky.DetectMode(modeType, os.Stdout)
+	if err != nil {
+		panic(err)
+	}
 
+	mode, err := dsky.NewMode(mt, dsky.WithVerbosity(int(verbosity)), dsky.WithTheme(th))
 	if err != nil {
 		panic(err)
 	}
+	printer := mode.Printer()
 
 	log := printer.Log().WithModule("broadcast")
+	log.Debug("broadcasting to 2 providers")
 	log.Warn("requesting deployment for group(s): westcoast")
 	log.Info("request accepted, deployment created with id: 81d79c80c4c7eb202cfd4846bb8e5328110cb299e9864674836b9fec6b536285")
 	log.WithModule("keys").Error("Unable to select a default key.\nToo many keys are stored locally to pick a default, a key is selected as the default only when there is a single key present.\nFound 3 keys instead of 1")
 	log.WithAction(dsky.LogActionDone).Info("request deployment for group(s): westcoast")
+	log.WithModule("bids").Error(dsky.Errorf("no bids for group 2: %w", errNoProviders).WithHint("retry with a higher price"))
 
 	gd := printer.NewSection("Groups").WithLabel("Deployment Status").NewData().AsList()
 	for _, g := range groups {
@@ -84,8 +103,12 @@ func main() {
 
 	printer.Flush()
 
-	mode.When(dsky.ModeTypeInteractive, func() error {
+	err = mode.When(dsky.ModeTypeInteractive, func() error {
 		fmt.Println("(this will only show in interactive mode)")
 		return nil
 	}).Run()
+	if err != nil {
+		log.Error(err)
+		os.Exit(dsky.ExitCode(err))
+	}
 }
@@ -5,92 +5,226 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
+	"time"
 
 	fc "github.com/fatih/color"
 	"github.com/gosuri/uitable"
 	"github.com/gosuri/uitable/util/strutil"
 )
 
-var (
-	MaxLogLabelWidth = 7
-	MaxLogLineWidth  = 140
-)
+// maxLogLabelWidth is the width the labels of the log lines are padded to
+const maxLogLabelWidth = 7
+
+// DefaultLineWidth is the width of the log lines when not writing to a terminal, unless set using WithWidth
+const DefaultLineWidth = 140
+
+// minLogMsgWidth is the width the messages are wrapped at on narrow terminals
+const minLogMsgWidth = 20
 
 type interactiveLogger struct {
 	module string
 	out    io.Writer
+	mu     *sync.Mutex // guards out, shared with the derived loggers
+	level  LogLevel
+	fields []field
 	LogAction
+
+	timestamps LogTimestamp
+	start      time.Time
+	now        func() time.Time
+	waits      *waitTimer    // shared with the derived loggers
+	spinners   *spinnerLines // shared with the derived loggers
+
+	term    *Terminal
+	theme   *Theme
+	palette *palette
 }
 
+// NewInteractiveLogger returns a logger that writes colored, wrapped log lines to out. The
+// colors and the width of the lines follow the capabilities of the terminal, see NewTerminal
 func NewInteractiveLogger(out io.Writer) *interactiveLogger {
-	return &interactiveLogger{out: out}
+	term := NewTerminal(out)
+	return &interactiveLogger{
+		term:     term,
+		theme:    DefaultTheme,
+		palette:  newPalette(DefaultTheme, term.Color()),
+		out:      out,
+		mu:       &sync.Mutex{},
+		level:    DefaultLogLevel(),
+		start:    time.Now(),
+		now:      time.Now,
+		waits:    newWaitTimer(),
+		spinners: newSpinnerLines(),
+	}
 }
 
-func (l *interactiveLogger) WithModule(module string) Logger {
-	l.module = module
+// WithTimestamps sets the style of the timestamps prefixed to the log lines
+func (l *interactiveLogger) WithTimestamps(ts LogTimestamp) *interactiveLogger {
+	l.timestamps = ts
 	return l
 }
 
-func (l *interactiveLogger) WithAction(action LogAction) Logger {
-	l.LogAction = action
+// WithClock sets the clock the timestamps and the durations are read from
+func (l *interactiveLogger) WithClock(now func() time.Time) *interactiveLogger {
+	l.now = now
+	l.start = now()
 	return l
 }
 
+// WithTheme sets the theme the log lines are styled with
+func (l *interactiveLogger) WithTheme(t *Theme) *interactiveLogger {
+	l.theme = t
+	l.palette = newPalette(t, l.term.Color())
+	return l
+}
+
+// WithModule returns a copy of the logger with the module set
+func (l *interactiveLogger) WithModule(module string) Logger {
+	nl := *l
+	nl.module = module
+	return &nl
+}
+
+// With returns a copy of the logger with the field added
+func (l *interactiveLogger) With(key string, value interface{}) Logger {
+	nl := *l
+	nl.fields = withField(l.fields, key, value)
+	return &nl
+}
+
+// WithFields returns a copy of the logger with the fields added
+func (l *interactiveLogger) WithFields(fields Fields) Logger {
+	nl := *l
+	nl.fields = withFields(l.fields, fields)
+	return &nl
+}
+
+// WithLevel returns a copy of the logger with the minimum level set
+func (l *interactiveLogger) WithLevel(level LogLevel) Logger {
+	nl := *l
+	nl.level = level
+	return &nl
+}
+
+func (l *interactiveLogger) Level() LogLevel {
+	return l.level
+}
+
+// WithAction returns a copy of the logger with the action set
+func (l *interactiveLogger) WithAction(action LogAction) Logger {
+	nl := *l
+	nl.LogAction = action
+	return &nl
+}
+
 func (l *interactiveLogger) Info(msg ...interface{}) LogItem {
-	lm := &logItem{
-		logModeType: logModeTypeInfo,
-		LogAction:   l.LogAction,
-		labelColor:  Color.Success,
-		msgColor:    Color.Hi,
-		msg:         msg,
-		module:      l.module,
-	}
-	l.writelog(lm)
-	return lm
+	return l.writeAction(logModeTypeInfo, l.LogAction, msg)
 }
 
 func (l *interactiveLogger) Debug(msg ...interface{}) LogItem {
-	lm := &logItem{
-		logModeType: logModeTypeDebug,
-		LogAction:   l.LogAction,
-		labelColor:  Color.Hi,
-		msgColor:    Color.Hi,
-		msg:         msg,
-		module:      l.module,
-	}
-	l.writelog(lm)
-	return lm
+	return l.writeAction(logModeTypeDebug, l.LogAction, msg)
 }
 
 func (l *interactiveLogger) Warn(msg ...interface{}) LogItem {
-	lm := &logItem{
-		logModeType: logModeTypeWarn,
-		LogAction:   l.LogAction,
-		labelColor:  Color.Notice,
-		msgColor:    Color.Normal,
-		msg:         msg,
-		module:      l.module,
-	}
-	l.writelog(lm)
-	return lm
+	return l.writeAction(logModeTypeWarn, l.LogAction, msg)
 }
 
 func (l *interactiveLogger) Error(msg ...interface{}) LogItem {
-	lm := &logItem{
-		logModeType: logModeTypeError,
-		LogAction:   l.LogAction,
-		labelColor:  Color.Failure,
-		msgColor:    Color.Normal,
-		msg:         msg,
-		module:      l.module,
+	return l.writeAction(logModeTypeError, l.LogAction, msg)
+}
+
+// Wait renders a spinner, updated in place, when writing to a terminal
+// and falls back to writing the log lines as they come otherwise
+func (l *interactiveLogger) Wait(msg ...interface{}) Task {
+	if !l.spins() {
+		return newLogTask(l.writeAction, msg)
+	}
+	return l.spinners.start(l, msg)
+}
+
+// spins returns true when the waits render a spinner
+func (l *interactiveLogger) spins() bool {
+	return l.term.IsTTY() && l.level <= LogLevelInfo
+}
+
+func (l *interactiveLogger) writeAction(level logModeType, action LogAction, msg []interface{}) LogItem {
+	if l.spins() && levelOf(level) >= l.level {
+		// the wait log items render a spinner like Wait does, that the
+		// done and fail log items of the same module finalize in place
+		switch action {
+		case LogActionWait:
+			l.spinners.start(l, msg)
+			lm := l.newLogItem(level, action, l.module, msg)
+			lm.fields = l.fields
+			return lm
+		case LogActionDone, LogActionFail:
+			if t := l.spinners.module(l.module); t != nil {
+				return t.finish(level, action, msg)
+			}
+		}
+	}
+	lm := l.newLogItem(level, action, l.module, msg)
+	lm.fields = l.fields
+	l.stamp(lm)
+	if levelOf(level) < l.level {
+		return lm
 	}
 	l.writelog(lm)
 	return lm
 }
 
+// stamp sets the timestamp of the log item and, when the item completes
+// a pending wait of the module, the time elapsed since the wait
+func (l *interactiveLogger) stamp(lm *logItem) {
+	now := l.now()
+	switch l.timestamps {
+	case LogTimestampAbsolute:
+		lm.timestamp = now.Format(logTimestampLayout)
+	case LogTimestampRelative:
+		lm.timestamp = "+" + fmtElapsed(now.Sub(l.start))
+	}
+	switch lm.LogAction {
+	case LogActionWait:
+		l.waits.start(lm.module, now)
+	case LogActionDone, LogActionFail:
+		lm.elapsed, lm.hasElapsed = l.waits.stop(lm.module, now)
+	}
+}
+
+// writelog writes the log item on its own line, above the spinner line if any
 func (l *interactiveLogger) writelog(lm LogItem) {
 	b, _ := lm.String()
-	fmt.Fprintln(l.out, b)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if line := l.spinners.line; len(line) > 0 {
+		// clear the spinner line, write the item and redraw the spinner below it
+		fmt.Fprint(l.out, clearLine+b+"\n"+line)
+		return
+	}
+	fmt.Fprint(l.out, b+"\n")
+}
+
+// write writes s to the output in a single write
+func (l *interactiveLogger) write(s string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprint(l.out, s)
+}
+
+// newLogItem returns the log item rendered using the colors and the width of the logger's terminal
+func (l *interactiveLogger) newLogItem(level logModeType, action LogAction, module string, msg []interface{}) *logItem {
+	lm := &logItem{
+		logModeType: level,
+		LogAction:   action,
+		module:      module,
+		labelColor:  l.palette.label(level, action),
+		msgColor:    l.palette.messages[level],
+		palette:     l.palette,
+		width:       l.term.Width(),
+	}
+	lm.msg, lm.report = presentErrors(msg)
+	return lm
 }
 
 type logItem struct {
@@ -100,6 +234,13 @@ type logItem struct {
 	msgColor   *fc.Color
 	msg        []interface{}
 	module     string
+	fields     []field
+	report     *errorReport
+	palette    *palette
+	width      int
+	timestamp  string
+	elapsed    time.Duration
+	hasElapsed bool
 }
 
 func (lm *logItem) WithLabelColor(color *fc.Color) *logItem {
@@ -118,7 +259,7 @@ func (lm *logItem) Bytes() ([]byte, error) {
 	if len(lm.LogAction) > 0 {
 		label = lm.labelColor.Sprintf("(%s)", lm.LogAction)
 	}
-	label = strutil.PadRight(label, MaxLogLabelWidth, ' ')
+	label = strutil.PadRight(label, maxLogLabelWidth, ' ')
 	msg := []string{}
 
 	if len(lm.module) > 0 {
@@ -137,7 +278,28 @@ func (lm *logItem) Bytes() ([]byte, error) {
 		}
 	}
 
-	buf.WriteString(prefixedMsg(label, strings.Join(msg, " "), MaxLogLineWidth))
+	for _, f := range lm.fields {
+		msg = append(msg, lm.palette.Dim.Sprintf("%s=%v", f.key, f.value))
+	}
+	if lm.hasElapsed {
+		msg = append(msg, lm.palette.Dim.Sprintf("(%s)", fmtElapsed(lm.elapsed)))
+	}
+	if len(lm.timestamp) > 0 {
+		label = lm.palette.Dim.Sprint(lm.timestamp) + " " + label
+	}
+
+	text := strings.Join(msg, " ")
+	if lm.report != nil {
+		for _, line := range lm.report.lines() {
+			text += "\n" + lm.palette.Dim.Sprint(line)
+		}
+	}
+	// wrap the message to fit the line, along with the label
+	width := lm.width - strutil.StringWidth(label) - 1
+	if width < minLogMsgWidth {
+		width = minLogMsgWidth
+	}
+	buf.WriteString(prefixedMsg(label, text, width))
 	return buf.Bytes(), nil
 }
 
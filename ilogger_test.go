@@ -0,0 +1,79 @@
+package dsky
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInteractiveLogger_Fields(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewInteractiveLogger(&buf).WithModule("deploy")
+	l.With("id", "abc").Info("created")
+	l.Info("next")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.Contains(lines[0], "[deploy] created id=abc") {
+		t.Errorf("expected fields suffix, actual %q", lines[0])
+	}
+	if strings.Contains(lines[1], "id=abc") {
+		t.Errorf("expected fields not to leak into the parent logger, actual %q", lines[1])
+	}
+}
+
+// fakeClock returns a clock that advances by step on every reading
+func fakeClock(start time.Time, step time.Duration) func() time.Time {
+	now := start
+	return func() time.Time {
+		t := now
+		now = now.Add(step)
+		return t
+	}
+}
+
+func TestInteractiveLogger_Timestamps(t *testing.T) {
+	start := time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+	var buf bytes.Buffer
+	l := NewInteractiveLogger(&buf).WithTimestamps(LogTimestampAbsolute)
+	l.now = fakeClock(start, 1500*time.Millisecond)
+	l.Info("first")
+	if !strings.HasPrefix(re.ReplaceAllString(buf.String(), ""), "15:04:05 (info)") {
+		t.Errorf("expected absolute timestamp prefix, actual %q", buf.String())
+	}
+
+	buf.Reset()
+	l.WithTimestamps(LogTimestampRelative)
+	l.start = start
+	l.Info("second")
+	if !strings.HasPrefix(re.ReplaceAllString(buf.String(), ""), "+1.5s (info)") {
+		t.Errorf("expected relative timestamp prefix, actual %q", buf.String())
+	}
+}
+
+func TestInteractiveLogger_Elapsed(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewInteractiveLogger(&buf)
+	l.now = fakeClock(time.Now(), 2*time.Second)
+	dl := l.WithModule("deploy")
+	dl.WithAction(LogActionWait).Info("deploying")
+	l.WithModule("keys").WithAction(LogActionDone).Info("unrelated")
+	dl.WithAction(LogActionDone).Info("deployed")
+	dl.WithAction(LogActionDone).Info("again")
+
+	lines := strings.Split(strings.TrimSpace(re.ReplaceAllString(buf.String(), "")), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, actual %q", lines)
+	}
+	for idx := range lines {
+		lines[idx] = strings.TrimSpace(lines[idx])
+	}
+	if !strings.HasSuffix(lines[1], "unrelated") {
+		t.Errorf("expected no elapsed time for another module, actual %q", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], "deployed (4s)") {
+		t.Errorf("expected elapsed time since the wait, actual %q", lines[2])
+	}
+	if !strings.HasSuffix(lines[3], "again") {
+		t.Errorf("expected the wait to be completed once, actual %q", lines[3])
+	}
+}
@@ -12,11 +12,19 @@ import (
 	"github.com/gosuri/uitable"
 )
 
+// InteractiveMode renders the sections as tables fitting the width of the terminal,
+// styled using the theme of the mode
 type InteractiveMode struct {
 	sections []Section
+	term     *Terminal
+	theme    *Theme
+	palette  *palette
 	common
 }
 
+// minColWidth is the width the table columns are wrapped at on narrow terminals
+const minColWidth = 12
+
 func NewInteractiveMode(out, errout io.Writer) *InteractiveMode {
 	if out == nil {
 		out = os.Stdout
@@ -26,17 +34,38 @@ func NewInteractiveMode(out, errout io.Writer) *InteractiveMode {
 	}
 	m := &InteractiveMode{
 		sections: make([]Section, 0),
+		term:     NewTerminal(out),
 	}
 	m.modeType = ModeTypeInteractive
 	m.out = out
 	m.errout = errout
 	m.logger = NewInteractiveLogger(errout)
+	m.setTheme(DefaultTheme)
 	return m
 }
 
+// Configure applies the options common to all the modes, along with the theme and the width
+// of the output
+func (m *InteractiveMode) Configure(o ModeOptions) {
+	m.common.Configure(o)
+	if o.Theme != nil {
+		m.setTheme(o.Theme)
+	}
+	if o.Width > 0 {
+		m.term.WithWidth(o.Width)
+	}
+}
+
+func (m *InteractiveMode) setTheme(t *Theme) {
+	m.theme = t
+	m.palette = newPalette(t, m.term.Color())
+}
+
 func (m *InteractiveMode) When(mtype ModeType, fn runF) Mode {
 	if mtype == m.modeType {
+		m.mu.Lock()
 		m.runners = append(m.runners, fn)
+		m.mu.Unlock()
 	}
 	return m
 }
@@ -51,23 +80,29 @@ func (i *InteractiveMode) Log() Logger {
 
 func (i *InteractiveMode) NewSection(id string) Section {
 	s := NewSection(id)
+	i.mu.Lock()
 	i.sections = append(i.sections, s)
+	i.mu.Unlock()
 	return s
 }
 
 func (i *InteractiveMode) WithSection(s Section) Printer {
+	i.mu.Lock()
 	i.sections = append(i.sections, s)
+	i.mu.Unlock()
 	return i
 }
 
 func (i *InteractiveMode) Flush() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
 	var buf bytes.Buffer
 	for _, sec := range i.sections {
-		if sec == nil {
+		if sec == nil || sec.Data() == nil {
 			continue
 		}
 		if len(sec.ID()) == 0 {
-			return errors.New("dksy: section needs a title")
+			return errors.New("dsky: section needs a title")
 		}
 
 		title := sec.ID()
@@ -75,7 +110,7 @@ func (i *InteractiveMode) Flush() error {
 			title = sec.Label()
 		}
 		buf.WriteString("\n")
-		buf.WriteString(NewTitle(title).H1().String())
+		buf.WriteString(i.title(title, 1))
 		buf.WriteString("\n")
 		d, err := sec.Data().Marshal(i)
 		if err != nil {
@@ -106,12 +141,13 @@ func (i *InteractiveMode) marshalSectionData(depth int, dv SectionData) ([]byte,
 	default:
 		return nil, fmt.Errorf("dsky: invalid section data style")
 	}
-	return nil, nil
 }
 
 func (i *InteractiveMode) formatSDPane(depth int, sectionData SectionData) ([]byte, error) {
 	wrapper := uitable.New()
 	wrapper.Wrap = true
+	wrapper.MaxColWidth = i.colWidth(2)
+	wrapper.Separator = i.theme.TableBorder
 	// for each ID, create a row in the wrapper table
 	for _, id := range sectionData.IDs() {
 		// fetch the items for the id
@@ -124,7 +160,7 @@ func (i *InteractiveMode) formatSDPane(depth int, sectionData SectionData) ([]by
 		if l := sectionData.Label(id); len(l) > 0 {
 			label = l
 		}
-		label = fmt.Sprintf("%s: ", label)
+		label += i.theme.KeyValueSeparator
 		// row items with the label as caption
 		ritems := []interface{}{label}
 		for _, v := range items {
@@ -144,6 +180,8 @@ func (i *InteractiveMode) formatSDPane(depth int, sectionData SectionData) ([]by
 func (i *InteractiveMode) formatSDList(depth int, sectionData SectionData) ([]byte, error) {
 	wrapper := uitable.New()
 	wrapper.Wrap = true
+	wrapper.MaxColWidth = i.colWidth(len(sectionData.IDs()))
+	wrapper.Separator = i.theme.TableBorder
 	// create the header column with ids as the captions
 	var headers []interface{}
 	//var ids []interface{}
@@ -156,12 +194,11 @@ func (i *InteractiveMode) formatSDList(depth int, sectionData SectionData) ([]by
 		if l := sectionData.Label(id); len(l) > 0 {
 			label = l
 		}
-		tl := NewTitle(label)
 		switch depth {
 		case 0:
-			label = tl.H2().String()
+			label = i.title(label, 2)
 		default:
-			label = tl.H3().String()
+			label = i.title(label, 3)
 		}
 		headers = append(headers, label)
 		if clc := len(sectionData.Data()[id]); clc > lc {
@@ -188,6 +225,36 @@ func (i *InteractiveMode) formatSDList(depth int, sectionData SectionData) ([]by
 	return wrapper.Bytes(), nil
 }
 
+// colWidth returns the width of the columns for the table to fit the terminal,
+// or zero for columns as wide as their content when the width is not known
+func (i *InteractiveMode) colWidth(cols int) uint {
+	if !i.term.hasWidth() || cols == 0 {
+		return 0
+	}
+	w := i.term.Width() / cols
+	if w < minColWidth {
+		w = minColWidth
+	}
+	return uint(w)
+}
+
+// title returns the title rendered using the heading style of the level
+func (i *InteractiveMode) title(text string, level int) string {
+	tl := NewTitle(text)
+	switch level {
+	case 1:
+		tl = tl.H1()
+	case 2:
+		tl = tl.H2()
+	default:
+		tl = tl.H3()
+	}
+	if hs, ok := i.theme.heading(level); ok {
+		tl = tl.WithStyle(hs, i.palette.heading(level))
+	}
+	return tl.String()
+}
+
 func (i *InteractiveMode) parsesd(v interface{}, depth int) (string, error) {
 	// return empty string when nil
 	if v == nil {
@@ -210,10 +277,10 @@ func (i *InteractiveMode) parsesd(v interface{}, depth int) (string, error) {
 		sort.Strings(keys)
 		for _, k := range keys {
 			v := item[k]
-			lines = append(lines, fmt.Sprintf("%s: %s", k, v))
+			lines = append(lines, k+i.theme.KeyValueSeparator+v)
 		}
 
-		buf.WriteString(strings.Join(lines, " | "))
+		buf.WriteString(strings.Join(lines, i.theme.ListSeparator))
 	default:
 		buf.WriteString(fmt.Sprintf("%v", item))
 	}
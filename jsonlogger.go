@@ -1,38 +1,158 @@
 package dsky
 
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
 type jsonLogger struct {
+	module string
+	out    io.Writer
+	mu     *sync.Mutex // guards out, shared with the derived loggers
+	level  LogLevel
+	fields []field
+	// recordType, when set, tags every log item so they can be told apart
+	// from the other records written to the same stream
+	recordType string
+	now        func() time.Time
+	LogAction
+}
+
+// NewJSONLogger returns a logger that writes each log item as a single line JSON object to out
+func NewJSONLogger(out io.Writer) *jsonLogger {
+	return &jsonLogger{out: out, mu: &sync.Mutex{}, level: DefaultLogLevel(), now: time.Now}
+}
+
+// WithClock sets the clock the timestamps are read from
+func (j *jsonLogger) WithClock(now func() time.Time) *jsonLogger {
+	j.now = now
+	return j
 }
 
 func (j *jsonLogger) Info(msg ...interface{}) LogItem {
-	return nil
+	return j.writelog(logModeTypeInfo, msg)
 }
 
 func (j *jsonLogger) Warn(msg ...interface{}) LogItem {
-	return nil
+	return j.writelog(logModeTypeWarn, msg)
 }
 
 func (j *jsonLogger) Error(msg ...interface{}) LogItem {
-	return nil
+	return j.writelog(logModeTypeError, msg)
 }
 
 func (j *jsonLogger) Debug(msg ...interface{}) LogItem {
-	return nil
+	return j.writelog(logModeTypeDebug, msg)
 }
 
-func (j *jsonLogger) WithModule(string) Logger {
-	return j
+// WithModule returns a copy of the logger with the module set
+func (j *jsonLogger) WithModule(module string) Logger {
+	nl := *j
+	nl.module = module
+	return &nl
 }
 
-func (j *jsonLogger) WithAction(LogAction) Logger {
-	return j
+// With returns a copy of the logger with the field added
+func (j *jsonLogger) With(key string, value interface{}) Logger {
+	nl := *j
+	nl.fields = withField(j.fields, key, value)
+	return &nl
+}
+
+// WithFields returns a copy of the logger with the fields added
+func (j *jsonLogger) WithFields(fields Fields) Logger {
+	nl := *j
+	nl.fields = withFields(j.fields, fields)
+	return &nl
 }
 
-type jsonLogItem struct{}
+// WithLevel returns a copy of the logger with the minimum level set
+func (j *jsonLogger) WithLevel(level LogLevel) Logger {
+	nl := *j
+	nl.level = level
+	return &nl
+}
+
+func (j *jsonLogger) Level() LogLevel {
+	return j.level
+}
+
+// WithAction returns a copy of the logger with the action set
+func (j *jsonLogger) WithAction(action LogAction) Logger {
+	nl := *j
+	nl.LogAction = action
+	return &nl
+}
+
+func (j *jsonLogger) Wait(msg ...interface{}) Task {
+	return newLogTask(j.writeAction, msg)
+}
+
+func (j *jsonLogger) writelog(level logModeType, msg []interface{}) LogItem {
+	return j.writeAction(level, j.LogAction, msg)
+}
+
+func (j *jsonLogger) writeAction(level logModeType, action LogAction, msg []interface{}) LogItem {
+	msg, report := presentErrors(msg)
+	lm := &jsonLogItem{
+		Type:      j.recordType,
+		Level:     level,
+		Action:    action,
+		Module:    j.module,
+		Message:   joinMsg(msg),
+		Error:     report,
+		Timestamp: j.now().UTC(),
+	}
+	if len(j.fields) > 0 {
+		lm.Fields = newJSONObject()
+		for _, f := range j.fields {
+			lm.Fields.set(f.key, f.value)
+		}
+	}
+	if j.out == nil || levelOf(level) < j.level {
+		return lm
+	}
+	if b, err := lm.Bytes(); err == nil {
+		j.mu.Lock()
+		fmt.Fprintln(j.out, string(b))
+		j.mu.Unlock()
+	}
+	return lm
+}
+
+type jsonLogItem struct {
+	Type      string       `json:"type,omitempty"`
+	Level     logModeType  `json:"level"`
+	Action    LogAction    `json:"action,omitempty"`
+	Module    string       `json:"module,omitempty"`
+	Message   string       `json:"message"`
+	Fields    *jsonObject  `json:"fields,omitempty"`
+	Error     *errorReport `json:"error,omitempty"`
+	Timestamp time.Time    `json:"timestamp"`
+}
 
 func (j *jsonLogItem) Bytes() ([]byte, error) {
-	return nil, nil
+	return json.Marshal(j)
 }
 
 func (j *jsonLogItem) String() (string, error) {
-	return "", nil
+	b, err := j.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// joinMsg formats the message parts the same way the interactive logger does
+// and strips any ANSI escape sequences from the result
+func joinMsg(msg []interface{}) string {
+	parts := make([]string, 0, len(msg))
+	for _, m := range msg {
+		parts = append(parts, fmt.Sprintf("%v", m))
+	}
+	return re.ReplaceAllString(strings.Join(parts, " "), "")
 }
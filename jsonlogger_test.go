@@ -0,0 +1,71 @@
+package dsky
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSONLogger_Info(t *testing.T) {
+	var buf bytes.Buffer
+	item := NewJSONLogger(&buf).WithModule("deploy").WithAction(LogActionDone).Info("created", 2, "groups")
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("expected valid json, got %q: %v", buf.String(), err)
+	}
+	want := map[string]string{"level": "info", "action": "done", "module": "deploy", "message": "created 2 groups"}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: expected %q, actual %q", k, v, got[k])
+		}
+	}
+	if _, ok := got["timestamp"]; !ok {
+		t.Error("expected timestamp field")
+	}
+
+	s, err := item.String()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != strings.TrimSpace(buf.String()) {
+		t.Errorf("expected %q, actual %q", strings.TrimSpace(buf.String()), s)
+	}
+}
+
+func TestJSONLogger_OneRecordPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewJSONLogger(&buf)
+	l.Warn("first")
+	l.Error("second\nline")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, actual %d: %q", len(lines), buf.String())
+	}
+}
+
+func TestJSONLogger_WithModuleCopies(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewJSONLogger(&buf)
+	l.WithModule("keys").WithAction(LogActionFail).Error("no key")
+	item := l.Info("next")
+	s, _ := item.String()
+	if strings.Contains(s, "keys") || strings.Contains(s, "fail") {
+		t.Errorf("expected module and action not to leak into the parent logger, actual %q", s)
+	}
+}
+
+func TestJSONLogger_Fields(t *testing.T) {
+	var buf bytes.Buffer
+	NewJSONLogger(&buf).With("deploy_id", "abc").WithFields(Fields{"count": 2}).Info("created")
+	var got struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Fields["deploy_id"] != "abc" || got.Fields["count"] != float64(2) {
+		t.Errorf("expected fields, actual %v", got.Fields)
+	}
+}
@@ -8,10 +8,14 @@ import (
 	"os"
 
 	"github.com/huandu/xstrings"
+	"github.com/itchyny/gojq"
 )
 
 type JSONMode struct {
 	sections []Section
+	query    *gojq.Query
+	asArray  bool
+	indent   string
 	common
 }
 
@@ -28,13 +32,46 @@ func NewJSONMode(out, errout io.Writer) *JSONMode {
 	m.modeType = ModeTypeJSON
 	m.out = out
 	m.errout = errout
-	m.logger = &jsonLogger{}
+	m.logger = NewJSONLogger(errout)
+	return m
+}
+
+// WithQuery sets a jq style expression, i.e: '.groups[].name', that is applied to the
+// document before it is written. An invalid expression is logged using the mode's
+// logger and returned
+func (m *JSONMode) WithQuery(expr string) (*JSONMode, error) {
+	m.query = nil
+	if len(expr) == 0 {
+		return m, nil
+	}
+	q, err := gojq.Parse(expr)
+	if err != nil {
+		err = fmt.Errorf("dsky: invalid query %q: %v", expr, err)
+		m.logger.Error(err)
+		return nil, err
+	}
+	m.query = q
+	return m, nil
+}
+
+// WithArray sets Flush to write the sections as an array of
+// objects instead of a single object keyed by the section ids
+func (m *JSONMode) WithArray(asArray bool) *JSONMode {
+	m.asArray = asArray
+	return m
+}
+
+// WithIndent sets Flush to pretty print the document using the indent
+func (m *JSONMode) WithIndent(indent string) *JSONMode {
+	m.indent = indent
 	return m
 }
 
 func (m *JSONMode) When(mtype ModeType, fn runF) Mode {
 	if mtype == m.modeType {
+		m.mu.Lock()
 		m.runners = append(m.runners, fn)
+		m.mu.Unlock()
 	}
 	return m
 }
@@ -45,33 +82,97 @@ func (i *JSONMode) Printer() Printer {
 
 func (i *JSONMode) NewSection(id string) Section {
 	s := NewSection(id)
+	i.mu.Lock()
 	i.sections = append(i.sections, s)
+	i.mu.Unlock()
 	return s
 }
 
 func (i *JSONMode) WithSection(s Section) Printer {
+	i.mu.Lock()
 	i.sections = append(i.sections, s)
+	i.mu.Unlock()
 	return i
 }
 
+// Flush writes the sections as a single JSON document. By default the document is an object
+// keyed by the section ids in the order the sections were added, with the raw tags of the
+// sections under "raw". When configured using WithArray, the document is an array of the
+// objects returned by MarshalSectionData. Otherwise, it returns ErrDuplicateSectionDataID
+// when two sections have the same snake cased id or a section is identified as "raw".
 func (i *JSONMode) Flush() error {
-	var buf bytes.Buffer
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	obj, raws, arr := newJSONObject(), newJSONObject(), make([]interface{}, 0)
 	for _, sec := range i.sections {
-		if sec == nil {
+		if sec == nil || sec.Data() == nil {
 			continue
 		}
-		b, err := sec.Data().Marshal(i)
+		res, err := jsonSectionDoc(sec.Data())
 		if err != nil {
 			return err
 		}
-		buf.Write(b)
+		if i.asArray {
+			arr = append(arr, res)
+			continue
+		}
+		id := xstrings.ToSnakeCase(sec.Data().Identifier())
+		if _, ok := obj.values[id]; ok || id == "raw" {
+			return ErrDuplicateSectionDataID{ID: id}
+		}
+		obj.set(id, res[id])
+		if raw, ok := res["raw"]; ok {
+			raws.set(id, raw)
+		}
 	}
-	_, err := fmt.Fprintln(i.out, buf.String())
+	if len(raws.keys) > 0 {
+		obj.set("raw", raws)
+	}
+	var doc interface{} = obj
+	if i.asArray {
+		doc = arr
+	}
+
+	var b []byte
+	var err error
+	if i.query != nil {
+		b, err = i.runQuery(doc)
+	} else {
+		b, err = i.marshal(doc)
+	}
+	if err != nil {
+		return err
+	}
+	if len(b) == 0 {
+		// the query has no results
+		return nil
+	}
+	_, err = fmt.Fprintln(i.out, string(b))
 	return err
 }
 
 func (i *JSONMode) MarshalSectionData(sectionData SectionData) ([]byte, error) {
-	d, err := i.marshalSectionData(sectionData)
+	res, err := jsonSectionDoc(sectionData)
+	if err != nil {
+		return nil, err
+	}
+	return i.marshal(res)
+}
+
+func (i *JSONMode) marshal(v interface{}) ([]byte, error) {
+	if len(i.indent) > 0 {
+		return json.MarshalIndent(v, "", i.indent)
+	}
+	return json.Marshal(v)
+}
+
+// jsonSectionDoc returns the records of the section data keyed by the snake cased
+// identifier, along with the raw tag of the section data if any
+func jsonSectionDoc(sectionData SectionData) (map[string]interface{}, error) {
+	if len(sectionData.Identifier()) == 0 {
+		return nil, ErrInvalidSectionDataID{}
+	}
+	d, err := sectionDataRecords(sectionData)
 	if err != nil {
 		return nil, err
 	}
@@ -80,10 +181,45 @@ func (i *JSONMode) MarshalSectionData(sectionData SectionData) ([]byte, error) {
 	if raw := sectionData.Tag("raw"); raw != nil {
 		res["raw"] = raw
 	}
-	return json.Marshal(res)
+	return res, nil
+}
+
+// runQuery applies the query to the document and returns the results, one per line,
+// nil when there are none
+func (i *JSONMode) runQuery(doc interface{}) ([]byte, error) {
+	// normalize the document into the generic types the query runs against
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	var results [][]byte
+	iter := i.query.Run(v)
+	for {
+		r, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := r.(error); ok {
+			err = fmt.Errorf("dsky: query failed: %v", err)
+			i.logger.Error(err)
+			return nil, err
+		}
+		rb, err := i.marshal(r)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rb)
+	}
+	return bytes.Join(results, []byte("\n")), nil
 }
 
-func (i *JSONMode) marshalSectionData(sectionData SectionData) (interface{}, error) {
+// sectionDataRecords returns the section data as a list of records keyed by the snake cased
+// column ids, with nested section data converted recursively
+func sectionDataRecords(sectionData SectionData) (interface{}, error) {
 	var recc int // record count
 	// get the records count
 	for _, id := range sectionData.IDs() {
@@ -93,18 +229,69 @@ func (i *JSONMode) marshalSectionData(sectionData SectionData) (interface{}, err
 	}
 	recs := make([]map[string]interface{}, recc)
 	for rowidx, row := range sectionData.Rows() {
-		recs[rowidx] = make(map[string]interface{})
-		for colidx, secdata := range row {
-			if v, ok := secdata.(SectionData); ok {
-				d, err := i.marshalSectionData(v)
-				if err != nil {
-					return nil, err
-				}
-				secdata = d
-			}
-			secname := xstrings.ToSnakeCase(sectionData.IDs()[colidx])
-			recs[rowidx][secname] = secdata
+		rec, err := sectionDataRecord(sectionData, row)
+		if err != nil {
+			return nil, err
 		}
+		recs[rowidx] = rec
 	}
 	return recs, nil
 }
+
+// sectionDataRecord returns a single row of the section data as a record
+func sectionDataRecord(sectionData SectionData, row []interface{}) (map[string]interface{}, error) {
+	rec := make(map[string]interface{})
+	ids := sectionData.IDs()
+	for colidx, secdata := range row {
+		if v, ok := secdata.(SectionData); ok {
+			d, err := sectionDataRecords(v)
+			if err != nil {
+				return nil, err
+			}
+			secdata = d
+		}
+		secname := xstrings.ToSnakeCase(ids[colidx])
+		rec[secname] = secdata
+	}
+	return rec, nil
+}
+
+// jsonObject is a JSON object that keeps the keys in the order they were set
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newJSONObject() *jsonObject {
+	return &jsonObject{values: make(map[string]interface{})}
+}
+
+func (o *jsonObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for idx, k := range o.keys {
+		if idx > 0 {
+			buf.WriteString(",")
+		}
+		kb, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		vb, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(kb)
+		buf.WriteString(":")
+		buf.Write(vb)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
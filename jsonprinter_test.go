@@ -0,0 +1,115 @@
+package dsky
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestJSONMode_Query(t *testing.T) {
+	var buf bytes.Buffer
+	m, err := NewMode(ModeTypeJSON, WithStdout(&buf), WithQuery(".groups[].name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Printer().NewSection("Groups").NewData().AsList().Add("Name", "west").Add("Name", "east")
+	if err := m.Printer().Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "\"west\"\n\"east\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
+
+func TestJSONMode_InvalidQuery(t *testing.T) {
+	var errout bytes.Buffer
+	if _, err := NewJSONMode(nil, &errout).WithQuery(".groups["); err == nil {
+		t.Error("expected error for invalid query")
+	}
+	if !strings.Contains(errout.String(), `"level":"error"`) || !strings.Contains(errout.String(), "invalid query") {
+		t.Errorf("expected the error to be logged, actual %q", errout.String())
+	}
+	if _, err := NewMode(ModeTypeJSON, WithStderr(&errout), WithQuery(".groups[")); err == nil {
+		t.Error("expected NewMode to return the error for invalid query")
+	}
+	if _, err := NewPrinter(ModeTypeJSON, WithStderr(&errout), WithQuery(".groups[")); err == nil {
+		t.Error("expected NewPrinter to return the error for invalid query")
+	}
+}
+
+func TestJSONMode_QueryNoResults(t *testing.T) {
+	var buf bytes.Buffer
+	m, err := NewMode(ModeTypeJSON, WithStdout(&buf), WithQuery(".groups[]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Printer().NewSection("Groups").NewData().AsList()
+	if err := m.Printer().Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() > 0 {
+		t.Errorf("expected no output, actual %q", buf.String())
+	}
+}
+
+func TestJSONMode_Flush(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewJSONMode(&buf, nil)
+	m.NewSection("Groups").NewData().Add("Name", "west").WithTag("raw", []string{"w"})
+	m.NewSection("Deployment").NewData().Add("DeployID", "abc")
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"groups":[{"name":"west"}],"deployment":[{"deploy_id":"abc"}],"raw":{"groups":["w"]}}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
+
+func TestJSONMode_FlushArrayIndent(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewJSONMode(&buf, nil).WithArray(true).WithIndent("  ")
+	m.NewSection("Groups").NewData().Add("Name", "west")
+	m.NewSection("Deployment").NewData().Add("DeployID", "abc")
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := `[
+  {
+    "groups": [
+      {
+        "name": "west"
+      }
+    ]
+  },
+  {
+    "deployment": [
+      {
+        "deploy_id": "abc"
+      }
+    ]
+  }
+]
+`
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\nactual:\n%s", want, got)
+	}
+}
+
+func TestJSONMode_FlushDuplicateID(t *testing.T) {
+	for _, ids := range [][]string{{"Groups", "groups"}, {"Raw"}} {
+		var buf bytes.Buffer
+		m := NewJSONMode(&buf, nil)
+		for _, id := range ids {
+			m.NewSection(id).NewData().Add("Name", "west")
+		}
+		err := m.Flush()
+		if _, ok := err.(ErrDuplicateSectionDataID); !ok {
+			t.Errorf("%v: expected ErrDuplicateSectionDataID, actual %v", ids, err)
+		}
+		if buf.Len() > 0 {
+			t.Errorf("%v: expected no output, actual %q", ids, buf.String())
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package dsky
 
+import "sort"
+
 type logModeType string
 type LogAction string
 
@@ -14,6 +16,12 @@ const (
 	LogActionFail           = "fail"
 )
 
+// Logger writes the log items of a mode, see the loggers returned by NewInteractiveLogger,
+// NewJSONLogger, NewShellLogger and NewSlogLogger.
+//
+// The With, WithFields, WithLevel, Level and Wait methods were added to the interface after
+// Info, Warn, Error, Debug, WithAction and WithModule: loggers implemented outside of this
+// package must add them to keep satisfying it. Wait can be implemented with NewLogTask.
 type Logger interface {
 	Info(msg ...interface{}) LogItem
 	Warn(msg ...interface{}) LogItem
@@ -21,9 +29,109 @@ type Logger interface {
 	Debug(msg ...interface{}) LogItem
 	WithAction(LogAction) Logger
 	WithModule(string) Logger
+
+	// With returns a logger that attaches the key/value field to the items it writes
+	With(key string, value interface{}) Logger
+
+	// WithFields returns a logger that attaches the fields to the items it writes
+	WithFields(Fields) Logger
+
+	// WithLevel returns a logger that only writes the items with the level or above
+	WithLevel(LogLevel) Logger
+
+	// Level returns the minimum level of the items the logger writes
+	Level() LogLevel
+
+	// Wait logs the message with LogActionWait and returns the Task
+	// to update the progress of and complete once it finishes
+	Wait(msg ...interface{}) Task
+}
+
+// Fields are structured key/value pairs attached to log items
+type Fields map[string]interface{}
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+// withField returns a copy of the fields with the key set, replacing the value of an existing key
+func withField(fields []field, key string, value interface{}) []field {
+	nf := make([]field, 0, len(fields)+1)
+	for _, f := range fields {
+		if f.key != key {
+			nf = append(nf, f)
+		}
+	}
+	return append(nf, field{key: key, value: value})
+}
+
+// withFields returns a copy of the fields with the keys of fs set in sorted order
+func withFields(fields []field, fs Fields) []field {
+	keys := make([]string, 0, len(fs))
+	for k := range fs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fields = withField(fields, k, fs[k])
+	}
+	return fields
 }
 
 type LogItem interface {
 	Bytes() ([]byte, error)
 	String() (string, error)
 }
+
+// Task is a long running operation started using Logger.Wait
+type Task interface {
+	// Progress sets the current progress of the task out of total
+	Progress(current, total int)
+
+	// Done completes the task with LogActionDone, logging the wait message if msg is empty
+	Done(msg ...interface{}) LogItem
+
+	// Fail completes the task with LogActionFail, logging the wait message if msg is empty
+	Fail(msg ...interface{}) LogItem
+}
+
+// logTask is a Task that writes a single log record when it starts and when it completes
+type logTask struct {
+	msg   []interface{}
+	write func(level logModeType, action LogAction, msg []interface{}) LogItem
+}
+
+// NewLogTask returns a Task that logs the message with LogActionWait using l, and the
+// completion of the task with LogActionDone or LogActionFail. It is the Wait of the
+// loggers that do not render a spinner.
+func NewLogTask(l Logger, msg ...interface{}) Task {
+	return newLogTask(func(level logModeType, action LogAction, msg []interface{}) LogItem {
+		if level == logModeTypeError {
+			return l.WithAction(action).Error(msg...)
+		}
+		return l.WithAction(action).Info(msg...)
+	}, msg)
+}
+
+func newLogTask(write func(logModeType, LogAction, []interface{}) LogItem, msg []interface{}) *logTask {
+	t := &logTask{msg: msg, write: write}
+	t.write(logModeTypeInfo, LogActionWait, msg)
+	return t
+}
+
+func (t *logTask) Progress(current, total int) {}
+
+func (t *logTask) Done(msg ...interface{}) LogItem {
+	if len(msg) == 0 {
+		msg = t.msg
+	}
+	return t.write(logModeTypeInfo, LogActionDone, msg)
+}
+
+func (t *logTask) Fail(msg ...interface{}) LogItem {
+	if len(msg) == 0 {
+		msg = t.msg
+	}
+	return t.write(logModeTypeError, LogActionFail, msg)
+}
@@ -0,0 +1,132 @@
+package dsky
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LogLevelEnv is the environment variable to set the default minimum log level with
+const LogLevelEnv = "DSKY_LOG_LEVEL"
+
+// LogLevel is the minimum level of the log items a logger writes
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+// ErrInvalidLogLevel is returned when parsing an unknown log level
+type ErrInvalidLogLevel struct {
+	Level string
+}
+
+func (e ErrInvalidLogLevel) Error() string {
+	return fmt.Sprintf("dsky: invalid log level %q, must be one of: debug, info, warn, error", e.Level)
+}
+
+func (e ErrInvalidLogLevel) ExitCode() int {
+	return ExitCodeUsage
+}
+
+// ParseLogLevel returns the log level for its name
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LogLevelDebug, nil
+	case "info":
+		return LogLevelInfo, nil
+	case "warn", "warning":
+		return LogLevelWarn, nil
+	case "error":
+		return LogLevelError, nil
+	default:
+		return LogLevelInfo, ErrInvalidLogLevel{Level: s}
+	}
+}
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	default:
+		return strconv.Itoa(int(l))
+	}
+}
+
+// Verbose returns the level lowered by n steps, i.e: LogLevelInfo.Verbose(1) is LogLevelDebug
+func (l LogLevel) Verbose(n int) LogLevel {
+	l -= LogLevel(n)
+	if l < LogLevelDebug {
+		return LogLevelDebug
+	}
+	if l > LogLevelError {
+		return LogLevelError
+	}
+	return l
+}
+
+// DefaultLogLevel returns the level set using the DSKY_LOG_LEVEL
+// environment variable, or LogLevelInfo when unset or invalid
+func DefaultLogLevel() LogLevel {
+	if v := os.Getenv(LogLevelEnv); len(v) > 0 {
+		if l, err := ParseLogLevel(v); err == nil {
+			return l
+		}
+	}
+	return LogLevelInfo
+}
+
+// levelOf returns the level of the log mode type
+func levelOf(t logModeType) LogLevel {
+	switch t {
+	case logModeTypeDebug:
+		return LogLevelDebug
+	case logModeTypeWarn:
+		return LogLevelWarn
+	case logModeTypeError:
+		return LogLevelError
+	default:
+		return LogLevelInfo
+	}
+}
+
+// Verbosity is a flag.Value that counts the number of times the flag is set,
+// so it can be used for -v style flags, i.e: flag.Var(&v, "v", "verbose output")
+type Verbosity int
+
+func (v *Verbosity) String() string {
+	if v == nil {
+		return "0"
+	}
+	return strconv.Itoa(int(*v))
+}
+
+// Set increments the verbosity, or sets it when given a number, i.e: -v=2
+func (v *Verbosity) Set(s string) error {
+	if s == "true" {
+		*v++
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("dsky: invalid verbosity %q", s)
+	}
+	*v = Verbosity(n)
+	return nil
+}
+
+// IsBoolFlag allows the flag to be set without a value
+func (v *Verbosity) IsBoolFlag() bool {
+	return true
+}
@@ -0,0 +1,83 @@
+package dsky
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+)
+
+func TestLogLevel_Filter(t *testing.T) {
+	loggers := map[string]func(*bytes.Buffer) Logger{
+		"interactive": func(b *bytes.Buffer) Logger { return NewInteractiveLogger(b) },
+		"json":        func(b *bytes.Buffer) Logger { return NewJSONLogger(b) },
+		"shell":       func(b *bytes.Buffer) Logger { return NewShellLogger(b) },
+	}
+	for name, newLogger := range loggers {
+		var buf bytes.Buffer
+		l := newLogger(&buf)
+		if l.Debug("hidden"); buf.Len() != 0 {
+			t.Errorf("%s: expected debug to be hidden by default, actual %q", name, buf.String())
+		}
+		if l.WithLevel(LogLevelDebug).Debug("shown"); buf.Len() == 0 {
+			t.Errorf("%s: expected debug to be written", name)
+		}
+		buf.Reset()
+		if l.WithLevel(LogLevelError).Warn("hidden"); buf.Len() != 0 {
+			t.Errorf("%s: expected warn to be hidden, actual %q", name, buf.String())
+		}
+	}
+}
+
+func TestLogLevel_Env(t *testing.T) {
+	defer os.Setenv(LogLevelEnv, os.Getenv(LogLevelEnv))
+	os.Setenv(LogLevelEnv, "debug")
+	if got := NewShellLogger(nil).Level(); got != LogLevelDebug {
+		t.Errorf("expected debug, actual %v", got)
+	}
+	m, err := NewMode(ModeTypeShell, WithLogLevel(LogLevelWarn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Printer().Log().Level(); got != LogLevelWarn {
+		t.Errorf("expected option to override the env, actual %v", got)
+	}
+}
+
+func TestWithVerbosity_Env(t *testing.T) {
+	t.Setenv(LogLevelEnv, "debug")
+	m, err := NewMode(ModeTypeShell, WithVerbosity(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Printer().Log().Level(); got != LogLevelDebug {
+		t.Errorf("expected the env level without -v, actual %v", got)
+	}
+
+	t.Setenv(LogLevelEnv, "error")
+	m, err = NewMode(ModeTypeShell, WithVerbosity(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Printer().Log().Level(); got != LogLevelWarn {
+		t.Errorf("expected the env level lowered by one, actual %v", got)
+	}
+}
+
+func TestVerbosity(t *testing.T) {
+	var v Verbosity
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&v, "v", "verbose")
+	if err := fs.Parse([]string{"-v", "-v"}); err != nil {
+		t.Fatal(err)
+	}
+	if v != 2 {
+		t.Errorf("expected 2, actual %d", v)
+	}
+	if got := LogLevelWarn.Verbose(int(v)); got != LogLevelDebug {
+		t.Errorf("expected debug, actual %v", got)
+	}
+	if _, err := ParseLogLevel("loud"); err == nil {
+		t.Error("expected error for invalid level")
+	}
+}
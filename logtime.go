@@ -0,0 +1,49 @@
+package dsky
+
+import (
+	"sync"
+	"time"
+)
+
+// LogTimestamp is the style of the timestamps prefixed to the interactive log lines
+type LogTimestamp int
+
+const (
+	// LogTimestampNone renders the log lines without timestamps
+	LogTimestampNone LogTimestamp = iota
+	// LogTimestampAbsolute prefixes the log lines with the time of day, i.e: 15:04:05
+	LogTimestampAbsolute
+	// LogTimestampRelative prefixes the log lines with the time elapsed since the mode started
+	LogTimestampRelative
+)
+
+// logTimestampLayout is the layout of the absolute timestamps
+const logTimestampLayout = "15:04:05"
+
+// waitTimer tracks when the pending wait log items of each module started
+type waitTimer struct {
+	mu    sync.Mutex
+	waits map[string]time.Time
+}
+
+func newWaitTimer() *waitTimer {
+	return &waitTimer{waits: make(map[string]time.Time)}
+}
+
+func (w *waitTimer) start(module string, t time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.waits[module] = t
+}
+
+// stop returns the time elapsed since the pending wait of the module, if any, started
+func (w *waitTimer) stop(module string, t time.Time) (time.Duration, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	started, ok := w.waits[module]
+	if !ok {
+		return 0, false
+	}
+	delete(w.waits, module)
+	return t.Sub(started), true
+}
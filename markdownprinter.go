@@ -0,0 +1,231 @@
+package dsky
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// MarkdownMode renders sections as markdown, lists as pipe tables and panes as bullet lists.
+// Nested section data is rendered as sub-tables under their own headings after the parent.
+type MarkdownMode struct {
+	sections []Section
+	common
+}
+
+func NewMarkdownMode(out, errout io.Writer) *MarkdownMode {
+	if out == nil {
+		out = os.Stdout
+	}
+	if errout == nil {
+		errout = os.Stderr
+	}
+	m := &MarkdownMode{
+		sections: make([]Section, 0),
+	}
+	m.modeType = ModeTypeMarkdown
+	m.out = out
+	m.errout = errout
+	m.logger = NewInteractiveLogger(errout)
+	return m
+}
+
+func (m *MarkdownMode) When(mtype ModeType, fn runF) Mode {
+	if mtype == m.modeType {
+		m.mu.Lock()
+		m.runners = append(m.runners, fn)
+		m.mu.Unlock()
+	}
+	return m
+}
+
+func (i *MarkdownMode) Printer() Printer {
+	return i
+}
+
+func (i *MarkdownMode) NewSection(id string) Section {
+	s := NewSection(id)
+	i.mu.Lock()
+	i.sections = append(i.sections, s)
+	i.mu.Unlock()
+	return s
+}
+
+func (i *MarkdownMode) WithSection(s Section) Printer {
+	i.mu.Lock()
+	i.sections = append(i.sections, s)
+	i.mu.Unlock()
+	return i
+}
+
+func (i *MarkdownMode) Flush() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	var buf bytes.Buffer
+	for _, sec := range i.sections {
+		if sec == nil || sec.Data() == nil {
+			continue
+		}
+		if len(sec.ID()) == 0 {
+			return errors.New("dsky: section needs a title")
+		}
+
+		title := sec.ID()
+		if len(sec.Label()) > 0 {
+			title = sec.Label()
+		}
+		buf.WriteString(NewTitle(title).H1().Markdown())
+		buf.WriteString("\n\n")
+		d, err := sec.Data().Marshal(i)
+		if err != nil {
+			return err
+		}
+		buf.Write(d)
+	}
+	_, err := fmt.Fprint(i.out, buf.String())
+	return err
+}
+
+func (i *MarkdownMode) MarshalSectionData(dv SectionData) ([]byte, error) {
+	return i.marshalSectionData(0, dv)
+}
+
+func (i *MarkdownMode) marshalSectionData(depth int, dv SectionData) ([]byte, error) {
+	if dv == nil {
+		return nil, nil
+	}
+	var (
+		buf  bytes.Buffer
+		subs []mdSubTable
+		err  error
+	)
+	switch dv.Style() {
+	case SectionDataStylePane:
+		subs, err = i.formatSDPane(&buf, dv)
+	case SectionDataStyleList:
+		subs, err = i.formatSDList(&buf, dv)
+	default:
+		return nil, fmt.Errorf("dsky: invalid section data style")
+	}
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	for _, sub := range subs {
+		tl := NewTitle(sub.title)
+		switch depth {
+		case 0:
+			tl = tl.H2()
+		default:
+			tl = tl.H3()
+		}
+		buf.WriteString(tl.Markdown())
+		buf.WriteString("\n\n")
+		d, err := i.marshalSectionData(depth+1, sub.data)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(d)
+	}
+	return buf.Bytes(), nil
+}
+
+// mdSubTable is nested section data to render after its parent
+type mdSubTable struct {
+	title string
+	data  SectionData
+}
+
+func (i *MarkdownMode) formatSDPane(buf *bytes.Buffer, sectionData SectionData) ([]mdSubTable, error) {
+	var subs []mdSubTable
+	for _, id := range sectionData.IDs() {
+		items := sectionData.Data()[id]
+		if len(items) == 0 {
+			continue
+		}
+		label := mdLabel(sectionData, id)
+		var vals []string
+		for _, v := range items {
+			if sd, ok := v.(SectionData); ok {
+				subs = append(subs, mdSubTable{title: label, data: sd})
+				vals = append(vals, fmt.Sprintf("see _%s_", label))
+				continue
+			}
+			vals = append(vals, mdListValue(mdValue(v)))
+		}
+		buf.WriteString(fmt.Sprintf("- **%s**: %s\n", label, strings.Join(vals, ", ")))
+	}
+	return subs, nil
+}
+
+func (i *MarkdownMode) formatSDList(buf *bytes.Buffer, sectionData SectionData) ([]mdSubTable, error) {
+	var subs []mdSubTable
+	ids := sectionData.IDs()
+	headers := make([]string, len(ids))
+	seps := make([]string, len(ids))
+	for idx, id := range ids {
+		headers[idx] = mdEscapeCell(mdLabel(sectionData, id))
+		seps[idx] = "---"
+	}
+	buf.WriteString(mdRow(headers))
+	buf.WriteString(mdRow(seps))
+	for rowIdx, row := range sectionData.Rows() {
+		cells := make([]string, len(ids))
+		for cellIdx, v := range row {
+			if sd, ok := v.(SectionData); ok {
+				title := fmt.Sprintf("%s (%d)", mdLabel(sectionData, ids[cellIdx]), rowIdx+1)
+				subs = append(subs, mdSubTable{title: title, data: sd})
+				cells[cellIdx] = fmt.Sprintf("see _%s_", mdEscapeCell(title))
+				continue
+			}
+			cells[cellIdx] = mdEscapeCell(mdValue(v))
+		}
+		buf.WriteString(mdRow(cells))
+	}
+	return subs, nil
+}
+
+func mdLabel(sectionData SectionData, id string) string {
+	if l := sectionData.Label(id); len(l) > 0 {
+		return l
+	}
+	return id
+}
+
+func mdValue(v interface{}) string {
+	switch item := v.(type) {
+	case nil:
+		return ""
+	case map[string]string:
+		var keys []string
+		for k := range item {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var lines []string
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("%s: %s", k, item[k]))
+		}
+		return re.ReplaceAllString(strings.Join(lines, ", "), "")
+	default:
+		return re.ReplaceAllString(fmt.Sprintf("%v", item), "")
+	}
+}
+
+// mdListValue breaks the lines of a list item value and indents them, so the value stays within the item
+func mdListValue(s string) string {
+	return strings.Replace(s, "\n", "  \n  ", -1)
+}
+
+func mdEscapeCell(s string) string {
+	s = strings.Replace(s, "|", "\\|", -1)
+	return strings.Replace(s, "\n", "<br>", -1)
+}
+
+func mdRow(cells []string) string {
+	return "| " + strings.Join(cells, " | ") + " |\n"
+}
@@ -0,0 +1,50 @@
+package dsky
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMarkdownMode_Flush(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewMarkdownMode(&buf, nil)
+	groups := NewSectionData("").AsList().
+		Add("Name", "west").Add("Region", "us|west").
+		Add("Name", "east").Add("Region", "us-east")
+	m.NewSection("Deployment").WithLabel("Deployment Status").NewData().AsPane().
+		Add("DeployID", "abc").WithLabel("DeployID", "Deployment ID").
+		Add("Groups", groups)
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := `# Deployment Status
+
+- **Deployment ID**: abc
+- **Groups**: see _Groups_
+
+## Groups
+
+| Name | Region |
+| --- | --- |
+| west | us\|west |
+| east | us-east |
+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\nactual:\n%s", want, got)
+	}
+}
+
+func TestMarkdownMode_PaneMultiline(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewMarkdownMode(&buf, nil)
+	m.NewSection("empty")
+	m.NewSection("keys").NewData().AsPane().Add("Error", "too many keys\nfound 3")
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "# keys\n\n- **Error**: too many keys  \n  found 3\n\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
@@ -1,8 +1,9 @@
 package dsky
 
 import (
-	"fmt"
 	"io"
+	"sync"
+	"time"
 )
 
 type ModeType string
@@ -11,15 +12,16 @@ const (
 	ModeTypeInteractive ModeType = "interactive"
 	ModeTypeShell                = "shell"
 	ModeTypeJSON                 = "json"
+	ModeTypeYAML                 = "yaml"
+	ModeTypeNDJSON               = "ndjson"
+	ModeTypeCSV                  = "csv"
+	ModeTypeTSV                  = "tsv"
+	ModeTypeMarkdown             = "markdown"
+	ModeTypeTemplate             = "template"
 )
 
-type runF func() error
-
-type ErrInvalidModeType struct{}
-
-func (e ErrInvalidModeType) Error() string {
-	return fmt.Sprintf("dsky: invalid mode type")
-}
+// runF is an alias, so that modes outside of the package can implement When
+type runF = func() error
 
 type Mode interface {
 	// Type must return the type of Mode
@@ -32,7 +34,8 @@ type Mode interface {
 	// current Mode when Run is invoked. It returns the current Mode
 	When(ModeType, runF) Mode
 
-	// Run runs the functions
+	// Run runs the functions and returns the first error, ExitCode
+	// maps it to the exit code of the process
 	Run() error
 
 	// Ask returns an Asker
@@ -42,36 +45,68 @@ type Mode interface {
 	IsInteractive() bool
 }
 
-func NewMode(m ModeType, stdout, errout io.Writer) (Mode, error) {
-	switch m {
-	case ModeTypeInteractive:
-		return NewInteractiveMode(stdout, errout), nil
-	case ModeTypeJSON:
-		return NewJSONMode(stdout, errout), nil
-	case ModeTypeShell:
-		return NewShellMode(stdout, errout), nil
-	default:
-		return nil, ErrInvalidModeType{}
+// NewMode returns a new mode of the type, created using the factory registered for the type.
+// The mode writes to os.Stdout and os.Stderr unless set using WithStdout and WithStderr, the
+// settings of each mode are independent of the other modes:
+//
+//	mode, err := dsky.NewMode(dsky.ModeTypeInteractive, dsky.WithStderr(&logs), dsky.WithWidth(80))
+func NewMode(m ModeType, opts ...Option) (Mode, error) {
+	factory, ok := modeFactory(m)
+	if !ok {
+		return nil, ErrInvalidModeType{Mode: m}
 	}
+	o := newOptions(opts)
+	mode, err := factory(o.out, o.errout, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := mode.(Configurer); ok {
+		c.Configure(o.modeOptions())
+	}
+	return mode, nil
+}
+
+// Configurer is implemented by the modes to apply the options common to all the modes. NewMode
+// calls Configure once the factory returns the mode, the modes registered using RegisterMode
+// must implement it, or embed a built-in mode, for the common options to take effect
+type Configurer interface {
+	Configure(ModeOptions)
+}
+
+// ModeOptions are the options common to all the modes, set using the options given to NewMode
+type ModeOptions struct {
+	Stdin      io.Reader        // set using WithStdin, nil when not set
+	Asker      Asker            // set using WithAsker, nil when not set
+	LogLevel   *LogLevel        // set using WithLogLevel or WithVerbosity, nil when not set
+	Timestamps LogTimestamp     // set using WithTimestamps
+	Theme      *Theme           // set using WithTheme, nil when not set
+	Width      int              // set using WithWidth, 0 when not set
+	Clock      func() time.Time // set using WithClock, nil when not set
+
+	ShellLogFormat ShellLogFormat // set using WithShellLogFormat, empty when not set
 }
 
 type common struct {
+	in       io.Reader
 	out      io.Writer
 	errout   io.Writer
 	modeType ModeType
 	runners  []runF
 	logger   Logger
 	asker    Asker
+	mu       sync.Mutex // guards the runners, the asker and the sections of the printers
 }
 
-func (c common) Log() Logger {
+func (c *common) Log() Logger {
 	return c.logger
 }
 
-func (m common) Run() error {
-	for i := 0; i < len(m.runners); i++ {
-		var fn runF
-		fn, m.runners = m.runners[0], m.runners[1:]
+// Run runs the functions registered for the mode in the order they were registered
+func (m *common) Run() error {
+	m.mu.Lock()
+	runners := append([]runF{}, m.runners...)
+	m.mu.Unlock()
+	for _, fn := range runners {
 		if err := fn(); err != nil {
 			return err
 		}
@@ -79,17 +114,59 @@ func (m common) Run() error {
 	return nil
 }
 
-func (m common) Type() ModeType {
+func (m *common) Type() ModeType {
 	return m.modeType
 }
 
-func (m common) Ask() Asker {
+// Ask returns the asker for the mode. Unless set otherwise, questions are read
+// from the mode's input and the prompts are written to errout
+func (m *common) Ask() Asker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.asker == nil {
-		m.asker = NewInteractiveAsker(m.Type())
+		m.asker = NewAsker(m.Type(), m.in, m.errout)
 	}
 	return m.asker
 }
 
-func (m common) IsInteractive() bool {
+// Configure applies the options common to all the modes
+func (m *common) Configure(o ModeOptions) {
+	if o.Stdin != nil {
+		m.in = o.Stdin
+	}
+	if o.Asker != nil {
+		m.asker = o.Asker
+	}
+	if o.LogLevel != nil {
+		m.logger = m.logger.WithLevel(*o.LogLevel)
+	}
+	switch l := m.logger.(type) {
+	case *interactiveLogger:
+		l.WithTimestamps(o.Timestamps)
+		if o.Theme != nil {
+			l.WithTheme(o.Theme)
+		}
+		if o.Width > 0 {
+			l.term.WithWidth(o.Width)
+		}
+		if o.Clock != nil {
+			l.WithClock(o.Clock)
+		}
+	case *jsonLogger:
+		if o.Clock != nil {
+			l.WithClock(o.Clock)
+		}
+	case *shellLogger:
+		if len(o.ShellLogFormat) > 0 {
+			m.logger = l.WithFormat(o.ShellLogFormat)
+		}
+	}
+	if sa, ok := m.asker.(*ScriptedAsker); ok && sa.log == nil {
+		// report the unanswered questions alongside the log of the mode
+		sa.WithLogger(m.logger)
+	}
+}
+
+func (m *common) IsInteractive() bool {
 	return m.modeType == ModeTypeInteractive
 }
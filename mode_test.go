@@ -0,0 +1,33 @@
+package dsky
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"testing"
+)
+
+func TestModes_ConcurrentPrinters(t *testing.T) {
+	modes := []ModeType{ModeTypeInteractive, ModeTypeJSON, ModeTypeShell, ModeTypeYAML,
+		ModeTypeNDJSON, ModeTypeCSV, ModeTypeTSV, ModeTypeMarkdown, ModeTypeTemplate}
+	for _, mt := range modes {
+		p, err := NewPrinter(mt, WithStdout(ioutil.Discard), WithStderr(ioutil.Discard), WithTemplate("{{.}}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		for n := 0; n < 8; n++ {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+				log := p.Log().WithModule(fmt.Sprintf("worker%d", n))
+				log.Info("adding section")
+				p.NewSection(fmt.Sprintf("section%d", n)).NewData().AsList().Add("Seq", n)
+				if err := p.Flush(); err != nil {
+					t.Error(err)
+				}
+			}(n)
+		}
+		wg.Wait()
+	}
+}
@@ -0,0 +1,267 @@
+package dsky
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/huandu/xstrings"
+)
+
+const (
+	ndjsonRecordTypeRow = "row"
+	ndjsonRecordTypeLog = "log"
+)
+
+// NDJSONMode streams section data as newline delimited JSON, one record per row. Rows of
+// sections created using NewSection are written as soon as they are complete, the remaining
+// rows are written on Flush. Each row is written once, the values set for a row after it is
+// written are not. Log items are written to the same stream as typed records.
+type NDJSONMode struct {
+	sections []Section
+	written  map[SectionData]int // number of rows written for the sections added using WithSection
+	outMu    *sync.Mutex         // guards out, shared by the streamed rows and the logger
+	common
+}
+
+func NewNDJSONMode(out, errout io.Writer) *NDJSONMode {
+	if out == nil {
+		out = os.Stdout
+	}
+	if errout == nil {
+		errout = os.Stderr
+	}
+	m := &NDJSONMode{
+		sections: make([]Section, 0),
+		written:  make(map[SectionData]int),
+		outMu:    &sync.Mutex{},
+	}
+	m.modeType = ModeTypeNDJSON
+	m.out = out
+	m.errout = errout
+	logger := NewJSONLogger(out)
+	logger.mu = m.outMu
+	logger.recordType = ndjsonRecordTypeLog
+	m.logger = logger
+	return m
+}
+
+func (m *NDJSONMode) When(mtype ModeType, fn runF) Mode {
+	if mtype == m.modeType {
+		m.mu.Lock()
+		m.runners = append(m.runners, fn)
+		m.mu.Unlock()
+	}
+	return m
+}
+
+func (i *NDJSONMode) Printer() Printer {
+	return i
+}
+
+func (i *NDJSONMode) NewSection(id string) Section {
+	s := &ndjsonSection{Section: NewSection(id), mode: i}
+	i.mu.Lock()
+	i.sections = append(i.sections, s)
+	i.mu.Unlock()
+	return s
+}
+
+func (i *NDJSONMode) WithSection(s Section) Printer {
+	i.mu.Lock()
+	i.sections = append(i.sections, s)
+	i.mu.Unlock()
+	return i
+}
+
+// Flush writes the rows that have not been written yet, so that the next Flush only writes
+// the rows added since
+func (i *NDJSONMode) Flush() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, sec := range i.sections {
+		if err := i.flushSection(sec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushSection writes the rows of the section that have not been written yet
+func (i *NDJSONMode) flushSection(sec Section) error {
+	if sec == nil || sec.Data() == nil {
+		return nil
+	}
+	switch sd := sec.Data().(type) {
+	case *ndjsonSectionData:
+		return sd.flush()
+	default:
+		to := len(sd.Rows())
+		if to <= i.written[sd] {
+			return nil
+		}
+		b, err := i.marshalRows(sd, i.written[sd], to)
+		if err != nil {
+			return err
+		}
+		i.written[sd] = to
+		i.outMu.Lock()
+		defer i.outMu.Unlock()
+		_, err = i.out.Write(b)
+		return err
+	}
+}
+
+func (i *NDJSONMode) MarshalSectionData(sectionData SectionData) ([]byte, error) {
+	return i.marshalRows(sectionData, 0, len(sectionData.Rows()))
+}
+
+// marshalRows returns the rows of the section data within [from, to) as NDJSON records
+func (i *NDJSONMode) marshalRows(sectionData SectionData, from, to int) ([]byte, error) {
+	var buf bytes.Buffer
+	rows := sectionData.Rows()
+	for rowidx := from; rowidx < to && rowidx < len(rows); rowidx++ {
+		rec, err := sectionDataRecord(sectionData, rows[rowidx])
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(ndjsonRecord{
+			Type:    ndjsonRecordTypeRow,
+			Section: xstrings.ToSnakeCase(sectionData.Identifier()),
+			Row:     rowidx,
+			Data:    rec,
+		})
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+type ndjsonRecord struct {
+	Type    string                 `json:"type"`
+	Section string                 `json:"section"`
+	Row     int                    `json:"row"`
+	Data    map[string]interface{} `json:"data"`
+}
+
+// ndjsonSection wraps a section so that the data it creates streams its rows
+type ndjsonSection struct {
+	Section
+	mode *NDJSONMode
+}
+
+func (s *ndjsonSection) NewData() SectionData {
+	sd := &ndjsonSectionData{SectionData: NewSectionData(s.ID()), mode: s.mode}
+	s.Section.WithData(sd)
+	return sd
+}
+
+func (s *ndjsonSection) WithID(id string) Section {
+	s.Section.WithID(id)
+	return s
+}
+
+func (s *ndjsonSection) WithData(data SectionData) Section {
+	s.Section.WithData(data)
+	return s
+}
+
+func (s *ndjsonSection) WithLabel(label string) Section {
+	s.Section.WithLabel(label)
+	return s
+}
+
+// ndjsonSectionData wraps section data and writes rows as they are added
+type ndjsonSectionData struct {
+	SectionData
+	mode    *NDJSONMode
+	written int  // number of rows already written
+	known   bool // whether all the columns are known, once a column is added to again
+}
+
+func (d *ndjsonSectionData) Add(id string, items ...interface{}) SectionData {
+	if len(d.Data()[id]) > 0 {
+		// the data moved on to the next row, or to the next values of the columns
+		d.known = true
+	}
+	d.SectionData.Add(id, items...)
+	if !d.known {
+		// the columns added later would be missing from the rows written so far
+		return d
+	}
+	if err := d.stream(completeRows(d)); err != nil {
+		d.mode.logger.Error(err)
+	}
+	return d
+}
+
+func (d *ndjsonSectionData) AsPane() SectionData {
+	d.SectionData.AsPane()
+	return d
+}
+
+func (d *ndjsonSectionData) AsList() SectionData {
+	d.SectionData.AsList()
+	return d
+}
+
+func (d *ndjsonSectionData) WithLabel(id, label string) SectionData {
+	d.SectionData.WithLabel(id, label)
+	return d
+}
+
+func (d *ndjsonSectionData) WithTag(tag string, msg interface{}) SectionData {
+	d.SectionData.WithTag(tag, msg)
+	return d
+}
+
+func (d *ndjsonSectionData) Hide(ids ...string) SectionData {
+	d.SectionData.Hide(ids...)
+	return d
+}
+
+func (d *ndjsonSectionData) flush() error {
+	return d.stream(len(d.Rows()))
+}
+
+// stream writes the rows up to the given count that have not been written yet
+func (d *ndjsonSectionData) stream(to int) error {
+	d.mode.outMu.Lock()
+	defer d.mode.outMu.Unlock()
+	if to <= d.written || len(d.Identifier()) == 0 {
+		return nil
+	}
+	b, err := d.mode.marshalRows(d.SectionData, d.written, to)
+	if err != nil {
+		return err
+	}
+	d.written = to
+	_, err = fmt.Fprint(d.mode.out, string(b))
+	return err
+}
+
+// completeRows returns the number of rows that are considered final. A row is final
+// once every column has a value for it and at least one column has moved on to the
+// next row
+func completeRows(sectionData SectionData) int {
+	min, max := -1, 0
+	for _, id := range sectionData.IDs() {
+		c := len(sectionData.Data()[id])
+		if min < 0 || c < min {
+			min = c
+		}
+		if c > max {
+			max = c
+		}
+	}
+	if n := max - 1; n < min {
+		return n
+	}
+	return min
+}
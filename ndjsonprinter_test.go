@@ -0,0 +1,87 @@
+package dsky
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNDJSONMode_Stream(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewNDJSONMode(&buf, nil)
+	d := m.NewSection("Groups").NewData().AsList()
+
+	d.Add("Seq", 1).Add("Name", "west")
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output before the row is complete, got %q", buf.String())
+	}
+	d.Add("Seq", 2)
+	want := `{"type":"row","section":"groups","row":0,"data":{"name":"west","seq":1}}` + "\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("expected %q, actual %q", want, got)
+	}
+
+	m.Log().Info("working")
+	d.Add("Name", "east")
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 records, actual %d: %q", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[1], `{"type":"log","level":"info"`) {
+		t.Errorf("expected log record, actual %q", lines[1])
+	}
+	want = `{"type":"row","section":"groups","row":1,"data":{"name":"east","seq":2}}`
+	if lines[2] != want {
+		t.Errorf("expected %q, actual %q", want, lines[2])
+	}
+}
+
+func TestNDJSONMode_StreamColumns(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewNDJSONMode(&buf, nil)
+	d := m.NewSection("Groups").NewData().AsList()
+
+	d.Add("Seq", 1, 2, 3).Add("Name", "a", "b", "c")
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output before the columns are known, got %q", buf.String())
+	}
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"row","section":"groups","row":0,"data":{"name":"a","seq":1}}
+{"type":"row","section":"groups","row":1,"data":{"name":"b","seq":2}}
+{"type":"row","section":"groups","row":2,"data":{"name":"c","seq":3}}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
+
+func TestNDJSONMode_FlushWithSection(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewNDJSONMode(&buf, nil)
+	s := NewSection("Groups")
+	s.NewData().Add("Name", "west")
+	m.WithSection(s)
+	for idx := 0; idx < 2; idx++ {
+		if err := m.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := `{"type":"row","section":"groups","row":0,"data":{"name":"west"}}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected the section to be written once, expected %q, actual %q", want, got)
+	}
+
+	s.Data().Add("Name", "east")
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want += `{"type":"row","section":"groups","row":1,"data":{"name":"east"}}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected the rows added since to be written, expected %q, actual %q", want, got)
+	}
+}
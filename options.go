@@ -0,0 +1,176 @@
+package dsky
+
+import (
+	"io"
+	"time"
+)
+
+// Option configures the Mode (or Printer) created by NewMode and NewPrinter
+type Option func(*options)
+
+type options struct {
+	out      io.Writer
+	errout   io.Writer
+	in       io.Reader
+	asker    Asker
+	level    *LogLevel
+	template string
+	query    string
+	asArray  bool
+	indent   string
+
+	timestamps LogTimestamp
+	theme      *Theme
+
+	width       int
+	shellPrefix string
+	shellFormat ShellLogFormat
+	now         func() time.Time
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// ResolveModeOptions returns the options common to all the modes set by opts, so that the
+// factories of the modes registered using RegisterMode can read the options they are given
+func ResolveModeOptions(opts ...Option) ModeOptions {
+	return newOptions(opts).modeOptions()
+}
+
+// modeOptions returns the options common to all the modes
+func (o *options) modeOptions() ModeOptions {
+	return ModeOptions{
+		Stdin:      o.in,
+		Asker:      o.asker,
+		LogLevel:   o.level,
+		Timestamps: o.timestamps,
+		Theme:      o.theme,
+		Width:      o.width,
+		Clock:      o.now,
+
+		ShellLogFormat: o.shellFormat,
+	}
+}
+
+// WithTemplate sets the text/template used to render the output of ModeTypeTemplate
+func WithTemplate(text string) Option {
+	return func(o *options) {
+		o.template = text
+	}
+}
+
+// WithQuery sets the jq style expression applied to the output of ModeTypeJSON, NewMode returns
+// an error for an invalid expression
+func WithQuery(expr string) Option {
+	return func(o *options) {
+		o.query = expr
+	}
+}
+
+// WithJSONArray sets ModeTypeJSON to write the sections as an array instead of an object
+func WithJSONArray() Option {
+	return func(o *options) {
+		o.asArray = true
+	}
+}
+
+// WithJSONIndent sets ModeTypeJSON to pretty print the output using the indent
+func WithJSONIndent(indent string) Option {
+	return func(o *options) {
+		o.indent = indent
+	}
+}
+
+// WithStdout sets the writer the output is written to, os.Stdout by default
+func WithStdout(out io.Writer) Option {
+	return func(o *options) {
+		o.out = out
+	}
+}
+
+// WithStderr sets the writer the log items and the prompts are written to, os.Stderr by default
+func WithStderr(errout io.Writer) Option {
+	return func(o *options) {
+		o.errout = errout
+	}
+}
+
+// WithStdin sets the reader the questions are read from
+func WithStdin(in io.Reader) Option {
+	return func(o *options) {
+		o.in = in
+	}
+}
+
+// WithAsker sets the asker of the mode, i.e: a ScriptedAsker in CI
+func WithAsker(a Asker) Option {
+	return func(o *options) {
+		o.asker = a
+	}
+}
+
+// WithLogLevel sets the minimum level of the items written by the mode's logger,
+// overriding the level set using the DSKY_LOG_LEVEL environment variable
+func WithLogLevel(level LogLevel) Option {
+	return func(o *options) {
+		o.level = &level
+	}
+}
+
+// WithVerbosity lowers the minimum log level by n steps from the default level, set
+// using DSKY_LOG_LEVEL, i.e: the count of -v flags, see Verbosity. It is a no-op when
+// n is not positive, so the default level applies
+func WithVerbosity(n int) Option {
+	if n <= 0 {
+		return func(*options) {}
+	}
+	return WithLogLevel(DefaultLogLevel().Verbose(n))
+}
+
+// WithTimestamps prefixes the interactive log lines with timestamps in the given style
+func WithTimestamps(ts LogTimestamp) Option {
+	return func(o *options) {
+		o.timestamps = ts
+	}
+}
+
+// WithTheme sets the theme the interactive output is styled with, see LoadTheme for custom themes
+func WithTheme(t *Theme) Option {
+	return func(o *options) {
+		o.theme = t
+	}
+}
+
+// WithWidth sets the width the interactive output is wrapped at, instead of the width of the terminal
+func WithWidth(width int) Option {
+	return func(o *options) {
+		o.width = width
+	}
+}
+
+// WithShellPrefix sets the prefix of the variable names written by ModeTypeShell, DefaultShellVarPrefix by default
+func WithShellPrefix(prefix string) Option {
+	return func(o *options) {
+		o.shellPrefix = prefix
+	}
+}
+
+// WithShellLogFormat sets the format of the log lines of ModeTypeShell and ModeTypeTemplate,
+// ShellLogFormatComment by default
+func WithShellLogFormat(format ShellLogFormat) Option {
+	return func(o *options) {
+		o.shellFormat = format
+	}
+}
+
+// WithClock sets the clock the timestamps and the durations of the log items are read from, i.e: a fixed time in tests
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
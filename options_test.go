@@ -0,0 +1,65 @@
+package dsky
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewMode_IndependentOptions(t *testing.T) {
+	var a, b bytes.Buffer
+	ma, err := NewMode(ModeTypeShell, WithStdout(&a), WithShellPrefix("app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mb, err := NewMode(ModeTypeShell, WithStdout(&b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []Mode{ma, mb} {
+		p := m.Printer()
+		p.NewSection("deploy").NewData().AsPane().Add("id", "abc")
+		if err := p.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !strings.Contains(a.String(), `app_deploy_0_id="abc"`) {
+		t.Errorf("expected the prefix set for the mode, actual %q", a.String())
+	}
+	if !strings.Contains(b.String(), DefaultShellVarPrefix+`_deploy_0_id="abc"`) {
+		t.Errorf("expected the default prefix, actual %q", b.String())
+	}
+}
+
+func TestNewMode_WithWidth(t *testing.T) {
+	var logs bytes.Buffer
+	m, err := NewMode(ModeTypeInteractive, WithStderr(&logs), WithWidth(40))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Printer().Log().Info(strings.Repeat("word ", 20))
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		if w := len(strings.TrimRight(re.ReplaceAllString(line, ""), " ")); w > 40 {
+			t.Errorf("expected lines of at most 40 columns, actual %d: %q", w, line)
+		}
+	}
+}
+
+func TestNewMode_WithClock(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var logs bytes.Buffer
+	m, err := NewMode(ModeTypeJSON, WithStderr(&logs), WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Printer().Log().Info("tick")
+	var got struct{ Timestamp time.Time }
+	if err := json.Unmarshal(logs.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Timestamp.Equal(now) {
+		t.Errorf("expected %v, actual %v", now, got.Timestamp)
+	}
+}
@@ -1,9 +1,5 @@
 package dsky
 
-import (
-	"io"
-)
-
 type Printer interface {
 	NewSection(id string) Section
 	WithSection(Section) Printer
@@ -11,15 +7,11 @@ type Printer interface {
 	Log() Logger
 }
 
-func NewPrinter(m ModeType, stdout, errout io.Writer) (Printer, error) {
-	switch m {
-	case ModeTypeInteractive:
-		return NewInteractiveMode(stdout, errout), nil
-	case ModeTypeJSON:
-		return NewJSONMode(stdout, errout), nil
-	case ModeTypeShell:
-		return NewShellMode(stdout, errout), nil
-	default:
-		return nil, ErrInvalidModeType{}
+// NewPrinter returns the printer of a new mode of the type, see NewMode
+func NewPrinter(m ModeType, opts ...Option) (Printer, error) {
+	mode, err := NewMode(m, opts...)
+	if err != nil {
+		return nil, err
 	}
+	return mode.Printer(), nil
 }
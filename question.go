@@ -0,0 +1,88 @@
+package dsky
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrInputClosed is returned when the input is closed before a question is answered
+type ErrInputClosed struct {
+	Question string
+}
+
+func (e ErrInputClosed) Error() string {
+	return fmt.Sprintf("dsky: input closed before answering %q", e.Question)
+}
+
+// ErrTooManyRetries is returned when a question is not answered
+// with a valid answer within the maximum number of retries
+type ErrTooManyRetries struct {
+	Question string
+	Retries  int
+}
+
+func (e ErrTooManyRetries) Error() string {
+	return fmt.Sprintf("dsky: no valid answer for %q after %d attempt(s)", e.Question, e.Retries)
+}
+
+// ErrAnswerRequired is reported when a required question is left unanswered
+type ErrAnswerRequired struct{}
+
+func (e ErrAnswerRequired) Error() string {
+	return "an answer is required"
+}
+
+// QuestionOption configures a question asked using StringVarE
+type QuestionOption func(*question)
+
+type question struct {
+	text     string
+	def      string
+	validate func(string) error
+	retries  int
+}
+
+func newQuestion(text string, opts []QuestionOption) *question {
+	q := &question{text: text}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// prompt returns the question text with the default answer, if any, in brackets
+func (q *question) prompt() string {
+	if len(q.def) == 0 {
+		return q.text
+	}
+	text := strings.TrimRight(q.text, " ")
+	// keep the trailing punctuation of the question after the default, i.e: "name [web]: "
+	var punct string
+	if strings.HasSuffix(text, ":") || strings.HasSuffix(text, "?") {
+		text, punct = text[:len(text)-1], text[len(text)-1:]
+	}
+	return fmt.Sprintf("%s [%s]%s ", text, q.def, punct)
+}
+
+// WithDefaultAnswer sets the answer used when the question is left empty
+func WithDefaultAnswer(def string) QuestionOption {
+	return func(q *question) {
+		q.def = def
+	}
+}
+
+// WithValidator sets the func to validate the answer with. The question
+// is asked again, after showing the error, when the answer is invalid
+func WithValidator(validate func(string) error) QuestionOption {
+	return func(q *question) {
+		q.validate = validate
+	}
+}
+
+// WithMaxRetries sets the maximum number of attempts to answer the
+// question. Zero, the default, allows any number of attempts
+func WithMaxRetries(n int) QuestionOption {
+	return func(q *question) {
+		q.retries = n
+	}
+}
@@ -0,0 +1,116 @@
+package dsky
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ModeFactory returns a new mode writing the output to stdout and the logs to errout, the
+// writers set using WithStdout and WithStderr, nil when not set. The options are the ones
+// given to NewMode or NewPrinter, ResolveModeOptions returns the options common to all the
+// modes they set. NewMode also applies them once the factory returns, to the modes that
+// implement Configurer
+type ModeFactory func(stdout, errout io.Writer, opts ...Option) (Mode, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[ModeType]ModeFactory)
+)
+
+// RegisterMode registers the factory NewMode and NewPrinter create the modes of the type
+// with, so applications can add their own modes. Registering a factory for a type that is
+// already registered, i.e: a built-in mode, replaces it. It panics when the type is empty
+// or the factory is nil
+func RegisterMode(m ModeType, factory ModeFactory) {
+	if len(m) == 0 || factory == nil {
+		panic("dsky: RegisterMode needs a mode type and a factory")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[m] = factory
+}
+
+// ModeTypes returns the registered mode types, sorted by name
+func ModeTypes() []ModeType {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	var res []ModeType
+	for m := range registry {
+		res = append(res, m)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+func modeFactory(m ModeType) (ModeFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	f, ok := registry[m]
+	return f, ok
+}
+
+// validateModeType returns ErrInvalidModeType when the mode type is not registered
+func validateModeType(m ModeType) error {
+	if _, ok := modeFactory(m); !ok {
+		return ErrInvalidModeType{Mode: m}
+	}
+	return nil
+}
+
+// ErrInvalidModeType is returned for a mode type that is not registered
+type ErrInvalidModeType struct {
+	Mode ModeType
+}
+
+func (e ErrInvalidModeType) Error() string {
+	var names []string
+	for _, m := range ModeTypes() {
+		names = append(names, string(m))
+	}
+	if len(e.Mode) == 0 {
+		return fmt.Sprintf("dsky: invalid mode type, must be one of: %s", strings.Join(names, ", "))
+	}
+	return fmt.Sprintf("dsky: invalid mode type %q, must be one of: %s", e.Mode, strings.Join(names, ", "))
+}
+
+func (e ErrInvalidModeType) ExitCode() int {
+	return ExitCodeUsage
+}
+
+func init() {
+	RegisterMode(ModeTypeInteractive, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		return NewInteractiveMode(stdout, errout), nil
+	})
+	RegisterMode(ModeTypeJSON, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		o := newOptions(opts)
+		m, err := NewJSONMode(stdout, errout).WithArray(o.asArray).WithIndent(o.indent).WithQuery(o.query)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+	RegisterMode(ModeTypeShell, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		return NewShellMode(stdout, errout).WithPrefix(newOptions(opts).shellPrefix), nil
+	})
+	RegisterMode(ModeTypeYAML, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		return NewYAMLMode(stdout, errout), nil
+	})
+	RegisterMode(ModeTypeNDJSON, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		return NewNDJSONMode(stdout, errout), nil
+	})
+	RegisterMode(ModeTypeCSV, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		return NewCSVMode(stdout, errout), nil
+	})
+	RegisterMode(ModeTypeTSV, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		return NewTSVMode(stdout, errout), nil
+	})
+	RegisterMode(ModeTypeMarkdown, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		return NewMarkdownMode(stdout, errout), nil
+	})
+	RegisterMode(ModeTypeTemplate, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		return NewTemplateMode(stdout, errout, newOptions(opts).template)
+	})
+}
@@ -0,0 +1,111 @@
+package dsky
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRegisterMode(t *testing.T) {
+	const audit ModeType = "audit"
+	var gotOpts int
+	RegisterMode(audit, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		gotOpts = len(opts)
+		return NewShellMode(stdout, errout), nil
+	})
+	defer func() {
+		registryMu.Lock()
+		delete(registry, audit)
+		registryMu.Unlock()
+	}()
+
+	var out bytes.Buffer
+	p, err := NewPrinter(audit, WithStdout(&out), WithLogLevel(LogLevelError))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotOpts != 2 {
+		t.Errorf("expected the options to be passed to the factory, actual %d", gotOpts)
+	}
+	if p.Log().Level() != LogLevelError {
+		t.Error("expected the common options to be applied to the mode")
+	}
+	p.NewSection("deploy").NewData().AsPane().Add("id", "abc")
+	if err := p.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "abc") {
+		t.Errorf("expected the output of the registered mode, actual %q", out.String())
+	}
+
+	var mode ModeType
+	if err := mode.Set("audit"); err != nil || mode != audit {
+		t.Errorf("expected the flag to accept the registered mode, actual %q (%v)", mode, err)
+	}
+}
+
+func TestNewMode_Invalid(t *testing.T) {
+	_, err := NewMode("fancy")
+	if _, ok := err.(ErrInvalidModeType); !ok {
+		t.Fatalf("expected ErrInvalidModeType, actual %v", err)
+	}
+	for _, m := range []string{`"fancy"`, "interactive", "json", "shell"} {
+		if !strings.Contains(err.Error(), m) {
+			t.Errorf("expected %s in %q", m, err.Error())
+		}
+	}
+}
+
+// configuredMode is a mode implemented outside of the built-in ones
+type configuredMode struct {
+	Mode
+	opts ModeOptions
+}
+
+func (m *configuredMode) Configure(o ModeOptions) {
+	m.opts = o
+}
+
+func TestRegisterMode_Configurer(t *testing.T) {
+	const audit ModeType = "audit"
+	RegisterMode(audit, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		return &configuredMode{Mode: NewShellMode(stdout, errout)}, nil
+	})
+	defer func() {
+		registryMu.Lock()
+		delete(registry, audit)
+		registryMu.Unlock()
+	}()
+
+	in := strings.NewReader("")
+	m, err := NewMode(audit, WithStdin(in), WithLogLevel(LogLevelWarn), WithWidth(80))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := m.(*configuredMode).opts
+	if o.Stdin != in || o.LogLevel == nil || *o.LogLevel != LogLevelWarn || o.Width != 80 {
+		t.Errorf("expected the common options to be passed to Configure, actual %+v", o)
+	}
+}
+
+func TestRegisterMode_ResolveModeOptions(t *testing.T) {
+	const audit ModeType = "audit"
+	var got ModeOptions
+	RegisterMode(audit, func(stdout, errout io.Writer, opts ...Option) (Mode, error) {
+		got = ResolveModeOptions(opts...)
+		return NewShellMode(stdout, errout), nil
+	})
+	defer func() {
+		registryMu.Lock()
+		delete(registry, audit)
+		registryMu.Unlock()
+	}()
+
+	if _, err := NewMode(audit, WithLogLevel(LogLevelDebug), WithShellLogFormat(ShellLogFormatKeyValue)); err != nil {
+		t.Fatal(err)
+	}
+	if got.LogLevel == nil || *got.LogLevel != LogLevelDebug || got.ShellLogFormat != ShellLogFormatKeyValue {
+		t.Errorf("expected the factory to read the options, actual %+v", got)
+	}
+}
@@ -0,0 +1,186 @@
+package dsky
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ErrUnansweredQuestion is the error of a ScriptedAsker when there is no valid answer
+// for a question, so that missing answers fail loudly in CI
+type ErrUnansweredQuestion struct {
+	Question string
+	Answer   string
+}
+
+func (e ErrUnansweredQuestion) Error() string {
+	if len(e.Answer) > 0 {
+		return fmt.Sprintf("dsky: invalid scripted answer %q for question %q", e.Answer, e.Question)
+	}
+	return fmt.Sprintf("dsky: no scripted answer for question %q", e.Question)
+}
+
+// ScriptedAsker is an Asker that answers the questions from a map keyed by
+// the question text, with the surrounding whitespace removed. Every question
+// needs an answer, an empty answer picks the default of the question. A missing
+// or invalid answer is an ErrUnansweredQuestion: StringVarE returns it, the
+// other methods log it as an error, return the default and keep it for Err
+type ScriptedAsker struct {
+	answers map[string]string
+	err     error
+	log     Logger
+}
+
+// NewScriptedAsker returns an asker that answers using the answers map
+func NewScriptedAsker(answers map[string]string) *ScriptedAsker {
+	a := &ScriptedAsker{answers: make(map[string]string)}
+	for q, v := range answers {
+		a.answers[strings.TrimSpace(q)] = v
+	}
+	return a
+}
+
+// LoadScriptedAsker returns an asker that answers using the question/answer
+// pairs in the YAML (or JSON) file at path
+func LoadScriptedAsker(path string) (*ScriptedAsker, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	answers := make(map[string]string)
+	if err := yaml.Unmarshal(b, &answers); err != nil {
+		return nil, fmt.Errorf("dsky: invalid answers file %s: %v", path, err)
+	}
+	return NewScriptedAsker(answers), nil
+}
+
+// WithLogger sets the logger the unanswered questions are logged to, the mode's logger
+// when the asker is set using WithAsker, and a logger writing to stderr otherwise
+func (s *ScriptedAsker) WithLogger(l Logger) *ScriptedAsker {
+	s.log = l
+	return s
+}
+
+// Err returns the first ErrUnansweredQuestion of the methods that cannot return an error
+func (s *ScriptedAsker) Err() error {
+	return s.err
+}
+
+func (s *ScriptedAsker) StringVar(str string, question string, required bool) string {
+	res, err := s.StringVarE(str, question, required)
+	s.fail(err)
+	return res
+}
+
+func (s *ScriptedAsker) StringVarE(str string, question string, required bool, opts ...QuestionOption) (string, error) {
+	if len(str) > 0 {
+		return str, nil
+	}
+	q := newQuestion(question, opts)
+	res, ok := s.answers[strings.TrimSpace(question)]
+	if !ok {
+		return "", ErrUnansweredQuestion{Question: question}
+	}
+	if len(res) == 0 {
+		res = q.def
+	}
+	if len(res) == 0 && required {
+		return "", ErrUnansweredQuestion{Question: question}
+	}
+	if q.validate != nil && len(res) > 0 {
+		if err := q.validate(res); err != nil {
+			return "", ErrUnansweredQuestion{Question: question, Answer: res}
+		}
+	}
+	return res, nil
+}
+
+func (s *ScriptedAsker) Confirm(question string, def bool) bool {
+	res, ok := s.answer(question)
+	if !ok || len(res) == 0 {
+		return def
+	}
+	yes, valid := parseConfirm(res)
+	if !valid {
+		s.fail(ErrUnansweredQuestion{Question: question, Answer: res})
+		return def
+	}
+	return yes
+}
+
+func (s *ScriptedAsker) Select(question string, options []string, def string) string {
+	res, ok := s.answer(question)
+	if !ok || len(res) == 0 {
+		return def
+	}
+	opt, valid := pickOption(options, res)
+	if !valid {
+		s.fail(ErrUnansweredQuestion{Question: question, Answer: res})
+		return def
+	}
+	return opt
+}
+
+func (s *ScriptedAsker) MultiSelect(question string, options []string, def []string) []string {
+	res, ok := s.answer(question)
+	if !ok || len(res) == 0 {
+		return def
+	}
+	var picked []string
+	for _, r := range strings.Split(res, ",") {
+		opt, valid := pickOption(options, strings.TrimSpace(r))
+		if !valid {
+			s.fail(ErrUnansweredQuestion{Question: question, Answer: res})
+			return def
+		}
+		picked = append(picked, opt)
+	}
+	return picked
+}
+
+func (s *ScriptedAsker) Password(str string, question string, required bool) string {
+	return s.StringVar(str, question, required)
+}
+
+func (s *ScriptedAsker) IntVar(val int, question string, validate func(int) error) int {
+	res, ok := s.answer(question)
+	if !ok || len(res) == 0 {
+		return val
+	}
+	n, err := strconv.Atoi(res)
+	if err == nil && validate != nil {
+		err = validate(n)
+	}
+	if err != nil {
+		s.fail(ErrUnansweredQuestion{Question: question, Answer: res})
+		return val
+	}
+	return n
+}
+
+// answer returns the answer to the question, failing when there is none
+func (s *ScriptedAsker) answer(question string) (string, bool) {
+	res, ok := s.answers[strings.TrimSpace(question)]
+	if !ok {
+		s.fail(ErrUnansweredQuestion{Question: question})
+	}
+	return res, ok
+}
+
+// fail logs err and keeps it unless an earlier error is kept
+func (s *ScriptedAsker) fail(err error) {
+	if err == nil {
+		return
+	}
+	if s.err == nil {
+		s.err = err
+	}
+	if s.log == nil {
+		s.log = NewInteractiveLogger(os.Stderr)
+	}
+	s.log.Error(err)
+}
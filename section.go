@@ -1,5 +1,7 @@
 package dsky
 
+import "sync"
+
 // Section represent a data section in the printer
 type Section interface {
 	// WithID set the section id with the provided string and returns the section
@@ -24,12 +26,13 @@ type Section interface {
 	Label() string
 }
 
-// NewSection creates and returns a new instance of a section
+// NewSection creates and returns a new instance of a section, safe for concurrent use
 func NewSection(id string) Section {
 	return &section{id: id}
 }
 
 type section struct {
+	mu    sync.RWMutex
 	id    string
 	data  SectionData
 	label string
@@ -40,28 +43,40 @@ func (s *section) WithID(string) Section {
 }
 
 func (s *section) ID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.id
 }
 
 func (s *section) NewData() SectionData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.data = NewSectionData(s.id)
 	return s.data
 }
 
 func (s *section) WithData(data SectionData) Section {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.data = data
 	return s
 }
 
 func (s *section) Data() SectionData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.data
 }
 
 func (s *section) WithLabel(l string) Section {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.label = l
 	return s
 }
 
 func (s *section) Label() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.label
 }
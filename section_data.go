@@ -1,5 +1,10 @@
 package dsky
 
+import (
+	"fmt"
+	"sync"
+)
+
 // ErrInvalidSectionDataID is an error that is
 // returned when the SectionData identifier is invalid or missing
 type ErrInvalidSectionDataID struct{}
@@ -9,6 +14,16 @@ func (e ErrInvalidSectionDataID) Error() string {
 	return "dsky: invalid or missing SectionData Identifier"
 }
 
+// ErrDuplicateSectionDataID is returned by the printers that key the sections by their
+// identifier when two sections have the same identifier, or one has a reserved identifier
+type ErrDuplicateSectionDataID struct {
+	ID string
+}
+
+func (e ErrDuplicateSectionDataID) Error() string {
+	return fmt.Sprintf("dsky: duplicate or reserved SectionData Identifier %q", e.ID)
+}
+
 // SectionDataMarshaler is the interface that discribes the
 // marshaler of section data
 type SectionDataMarshaler interface {
@@ -71,12 +86,14 @@ type SectionData interface {
 	Hide(ids ...string) SectionData
 }
 
-// NewSectionData returns a new instance of SectionData
+// NewSectionData returns a new instance of SectionData. The section data is safe for concurrent
+// use, so that a printer can be flushed while the data of another section is being added
 func NewSectionData(id string) SectionData {
 	return &sectionData{id: id}
 }
 
 type sectionData struct {
+	mu        sync.RWMutex
 	id        string
 	style     SectionDataStyle
 	data      map[string][]interface{}
@@ -87,26 +104,34 @@ type sectionData struct {
 }
 
 func (d *sectionData) Marshal(m SectionDataMarshaler) ([]byte, error) {
-	if len(d.id) == 0 {
+	if len(d.Identifier()) == 0 {
 		return nil, ErrInvalidSectionDataID{}
 	}
 	return m.MarshalSectionData(d)
 }
 
 func (d *sectionData) Identifier() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
 	return d.id
 }
 
 func (d *sectionData) Style() SectionDataStyle {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
 	return d.style
 }
 
 func (d *sectionData) AsPane() SectionData {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.style = SectionDataStylePane
 	return d
 }
 
 func (d *sectionData) WithTag(tag string, msg interface{}) SectionData {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	if d.tags == nil {
 		d.tags = make(map[string]interface{})
 	}
@@ -115,19 +140,40 @@ func (d *sectionData) WithTag(tag string, msg interface{}) SectionData {
 }
 
 func (d *sectionData) AsList() SectionData {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.style = SectionDataStyleList
 	return d
 }
 
 func (d *sectionData) Tag(tag string) interface{} {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
 	return d.tags[tag]
 }
 
+// Data returns a copy of the map of children, so that it can be read while items are added
 func (d *sectionData) Data() map[string][]interface{} {
-	return d.data
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.data == nil {
+		return nil
+	}
+	res := make(map[string][]interface{}, len(d.data))
+	for id, items := range d.data {
+		res[id] = items[:len(items):len(items)]
+	}
+	return res
 }
 
 func (d *sectionData) IDs() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.visibleIDs()
+}
+
+// visibleIDs returns the ids that are not hidden, the caller must hold the lock
+func (d *sectionData) visibleIDs() []string {
 	var retids []string
 outLoop:
 	for _, id := range d.ids {
@@ -142,6 +188,8 @@ outLoop:
 }
 
 func (d *sectionData) WithLabel(id, label string) SectionData {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	if d.labels == nil {
 		d.labels = make(map[string]string)
 	}
@@ -150,23 +198,28 @@ func (d *sectionData) WithLabel(id, label string) SectionData {
 }
 
 func (d *sectionData) Label(id string) (l string) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
 	return d.labels[id]
 }
 
 func (d *sectionData) Rows() [][]interface{} {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	ids := d.visibleIDs()
 	var rowc int // record count
-	for _, id := range d.IDs() {
-		if c := len(d.Data()[id]); c > rowc {
+	for _, id := range ids {
+		if c := len(d.data[id]); c > rowc {
 			rowc = c
 		}
 	}
 	rows := make([][]interface{}, rowc)
 	for rowidx := 0; rowidx < rowc; rowidx++ {
-		rows[rowidx] = make([]interface{}, len(d.IDs()))
-		for colidx := 0; colidx < len(d.IDs()); colidx++ {
-			secname := d.IDs()[colidx]
-			if len(d.Data()[secname]) > rowidx {
-				val := d.Data()[secname][rowidx]
+		rows[rowidx] = make([]interface{}, len(ids))
+		for colidx := 0; colidx < len(ids); colidx++ {
+			secname := ids[colidx]
+			if len(d.data[secname]) > rowidx {
+				val := d.data[secname][rowidx]
 				if val == nil {
 					rows[rowidx][colidx] = ""
 					continue
@@ -178,23 +231,29 @@ func (d *sectionData) Rows() [][]interface{} {
 	return rows
 }
 func (d *sectionData) Hide(ids ...string) SectionData {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.hiddenIDs = append(d.hiddenIDs, ids...)
 	return d
 }
 
 func (d *sectionData) Add(id string, items ...interface{}) SectionData {
-	if d.data == nil {
-		d.data = make(map[string][]interface{})
-	}
 	for i := 0; i < len(items); i++ {
 		switch sd := items[i].(type) {
 		case *sectionData:
-			if len(sd.Identifier()) == 0 {
+			sd.mu.Lock()
+			if len(sd.id) == 0 {
 				sd.id = id
 			}
+			sd.mu.Unlock()
 			items[i] = sd
 		}
 	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.data == nil {
+		d.data = make(map[string][]interface{})
+	}
 	d.data[id] = append(d.data[id], items...)
 	// avoid duplicate ids
 	for _, l := range d.ids {
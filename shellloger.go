@@ -1,38 +1,202 @@
 package dsky
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/huandu/xstrings"
+)
+
+// ShellLogFormat is the format of the log lines written by the shell logger
+type ShellLogFormat string
+
+const (
+	// ShellLogFormatComment renders log items as shell comments, i.e: # info [module] message
+	ShellLogFormatComment ShellLogFormat = "comment"
+	// ShellLogFormatKeyValue renders log items as key=value pairs, i.e: level=info module=module message="message"
+	ShellLogFormatKeyValue ShellLogFormat = "keyvalue"
+)
+
 type shellLogger struct {
+	module string
+	out    io.Writer
+	mu     *sync.Mutex // guards out, shared with the derived loggers
+	level  LogLevel
+	fields []field
+	format ShellLogFormat
+	LogAction
+}
+
+// NewShellLogger returns a logger that writes plain, ANSI-stripped log lines to out,
+// formatted using ShellLogFormatComment
+func NewShellLogger(out io.Writer) *shellLogger {
+	return &shellLogger{out: out, mu: &sync.Mutex{}, level: DefaultLogLevel(), format: ShellLogFormatComment}
+}
+
+// WithFormat returns a copy of the logger with the format of the log lines set
+func (j *shellLogger) WithFormat(format ShellLogFormat) *shellLogger {
+	nl := *j
+	nl.format = format
+	return &nl
 }
 
 func (j *shellLogger) Info(msg ...interface{}) LogItem {
-	return nil
+	return j.writelog(logModeTypeInfo, msg)
 }
 
 func (j *shellLogger) Warn(msg ...interface{}) LogItem {
-	return nil
+	return j.writelog(logModeTypeWarn, msg)
 }
 
 func (j *shellLogger) Error(msg ...interface{}) LogItem {
-	return nil
+	return j.writelog(logModeTypeError, msg)
 }
 
 func (j *shellLogger) Debug(msg ...interface{}) LogItem {
-	return nil
+	return j.writelog(logModeTypeDebug, msg)
+}
+
+// WithModule returns a copy of the logger with the module set
+func (j *shellLogger) WithModule(module string) Logger {
+	nl := *j
+	nl.module = module
+	return &nl
 }
 
-func (j *shellLogger) WithModule(string) Logger {
-	return j
+// With returns a copy of the logger with the field added
+func (j *shellLogger) With(key string, value interface{}) Logger {
+	nl := *j
+	nl.fields = withField(j.fields, key, value)
+	return &nl
 }
 
-func (j *shellLogger) WithAction(LogAction) Logger {
-	return j
+// WithFields returns a copy of the logger with the fields added
+func (j *shellLogger) WithFields(fields Fields) Logger {
+	nl := *j
+	nl.fields = withFields(j.fields, fields)
+	return &nl
 }
 
-type shellLogItem struct{}
+// WithLevel returns a copy of the logger with the minimum level set
+func (j *shellLogger) WithLevel(level LogLevel) Logger {
+	nl := *j
+	nl.level = level
+	return &nl
+}
+
+func (j *shellLogger) Level() LogLevel {
+	return j.level
+}
+
+// WithAction returns a copy of the logger with the action set
+func (j *shellLogger) WithAction(action LogAction) Logger {
+	nl := *j
+	nl.LogAction = action
+	return &nl
+}
+
+func (j *shellLogger) Wait(msg ...interface{}) Task {
+	return newLogTask(j.writeAction, msg)
+}
+
+func (j *shellLogger) writelog(level logModeType, msg []interface{}) LogItem {
+	return j.writeAction(level, j.LogAction, msg)
+}
+
+func (j *shellLogger) writeAction(level logModeType, action LogAction, msg []interface{}) LogItem {
+	msg, report := presentErrors(msg)
+	lm := &shellLogItem{
+		logModeType: level,
+		LogAction:   action,
+		module:      j.module,
+		msg:         joinMsg(msg),
+		report:      report,
+		fields:      j.fields,
+		format:      j.format,
+	}
+	if j.out == nil || levelOf(level) < j.level {
+		return lm
+	}
+	if b, err := lm.String(); err == nil {
+		j.mu.Lock()
+		fmt.Fprintln(j.out, b)
+		j.mu.Unlock()
+	}
+	return lm
+}
+
+type shellLogItem struct {
+	logModeType
+	LogAction
+	module string
+	msg    string
+	fields []field
+	report *errorReport
+	format ShellLogFormat
+}
 
 func (j *shellLogItem) Bytes() ([]byte, error) {
-	return nil, nil
+	var buf bytes.Buffer
+	switch j.format {
+	case ShellLogFormatKeyValue:
+		buf.WriteString(fmt.Sprintf("level=%s", j.logModeType))
+		if len(j.LogAction) > 0 {
+			buf.WriteString(fmt.Sprintf(" action=%s", j.LogAction))
+		}
+		if len(j.module) > 0 {
+			buf.WriteString(fmt.Sprintf(" module=%q", j.module))
+		}
+		buf.WriteString(fmt.Sprintf(" message=%q", j.msg))
+		if j.report != nil {
+			buf.WriteString(fmt.Sprintf(" error=%q", j.report.Message))
+			if len(j.report.Hint) > 0 {
+				buf.WriteString(fmt.Sprintf(" hint=%q", j.report.Hint))
+			}
+		}
+		buf.WriteString(j.fieldVars())
+	default:
+		label := string(j.logModeType)
+		if len(j.LogAction) > 0 {
+			label = string(j.LogAction)
+		}
+		prefix := "# " + label
+		if len(j.module) > 0 {
+			prefix = fmt.Sprintf("%s [%s]", prefix, j.module)
+		}
+		// comment out every line so multi-line messages stay eval safe
+		msg := j.msg + j.fieldVars()
+		if j.report != nil {
+			msg = strings.Join(append([]string{msg}, j.report.lines()...), "\n")
+		}
+		lines := strings.Split(msg, "\n")
+		for i, line := range lines {
+			if i == 0 {
+				buf.WriteString(prefix + " " + line)
+				continue
+			}
+			buf.WriteString("\n# " + line)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// fieldVars returns the fields as space prefixed shell variables, i.e: ` deploy_id="abc"`
+func (j *shellLogItem) fieldVars() string {
+	var buf bytes.Buffer
+	for _, f := range j.fields {
+		val := re.ReplaceAllString(fmt.Sprintf("%v", f.value), "")
+		buf.WriteString(fmt.Sprintf(" %s=%q", xstrings.ToSnakeCase(f.key), val))
+	}
+	return buf.String()
 }
 
 func (j *shellLogItem) String() (string, error) {
-	return "", nil
+	b, err := j.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
 }
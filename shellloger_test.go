@@ -0,0 +1,52 @@
+package dsky
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestShellLogger_Comment(t *testing.T) {
+	var buf bytes.Buffer
+	item := NewShellLogger(&buf).WithModule("keys").Error(newPalette(ThemeDark, true).labels[logModeTypeError].Sprint("no key"), "found\nexpected 1")
+	want := "# error [keys] no key found\n# expected 1\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+	if s, _ := item.String(); s+"\n" != want {
+		t.Errorf("expected %q, actual %q", want, s)
+	}
+}
+
+func TestShellLogger_KeyValue(t *testing.T) {
+	var buf bytes.Buffer
+	NewShellLogger(&buf).WithFormat(ShellLogFormatKeyValue).WithAction(LogActionDone).Info("deployed")
+	want := "level=info action=done message=\"deployed\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
+
+func TestShellLogger_Fields(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewShellLogger(&buf).With("DeployID", "abc")
+	l.Info("created")
+	l.(*shellLogger).WithFormat(ShellLogFormatKeyValue).Info("created")
+	want := "# info created deploy_id=\"abc\"\n" +
+		"level=info message=\"created\" deploy_id=\"abc\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
+
+func TestShellMode_WithShellLogFormat(t *testing.T) {
+	var buf bytes.Buffer
+	m, err := NewMode(ModeTypeShell, WithStderr(&buf), WithShellLogFormat(ShellLogFormatKeyValue))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Printer().Log().Info("deployed")
+	want := "level=info message=\"deployed\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
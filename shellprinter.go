@@ -15,13 +15,14 @@ import (
 
 const ansi = "[\u001B\u009B][[\\]()#;?]*(?:(?:(?:[a-zA-Z\\d]*(?:;[a-zA-Z\\d]*)*)?\u0007)|(?:(?:\\d{1,4}(?:;\\d{0,4})*)?[\\dA-PRZcf-ntqry=><~]))"
 
-var (
-	ShellVarPrefix = "akash"
-	re             = regexp.MustCompile(ansi)
-)
+// DefaultShellVarPrefix is the prefix of the variable names, unless set using WithShellPrefix
+const DefaultShellVarPrefix = "akash"
+
+var re = regexp.MustCompile(ansi)
 
 type ShellMode struct {
 	sections []Section
+	prefix   string
 	common
 }
 
@@ -34,11 +35,20 @@ func NewShellMode(out, errout io.Writer) *ShellMode {
 	}
 	s := &ShellMode{
 		sections: make([]Section, 0),
+		prefix:   DefaultShellVarPrefix,
 	}
 	s.modeType = ModeTypeShell
 	s.out = out
 	s.errout = errout
-	s.logger = &shellLogger{}
+	s.logger = NewShellLogger(errout)
+	return s
+}
+
+// WithPrefix sets the prefix of the variable names, the prefix is left as is when empty
+func (s *ShellMode) WithPrefix(prefix string) *ShellMode {
+	if len(prefix) > 0 {
+		s.prefix = prefix
+	}
 	return s
 }
 
@@ -48,26 +58,34 @@ func (i *ShellMode) Printer() Printer {
 
 func (m *ShellMode) When(mtype ModeType, fn runF) Mode {
 	if mtype == m.modeType {
+		m.mu.Lock()
 		m.runners = append(m.runners, fn)
+		m.mu.Unlock()
 	}
 	return m
 }
 
 func (i *ShellMode) NewSection(id string) Section {
 	s := NewSection(id)
+	i.mu.Lock()
 	i.sections = append(i.sections, s)
+	i.mu.Unlock()
 	return s
 }
 
 func (i *ShellMode) WithSection(s Section) Printer {
+	i.mu.Lock()
 	i.sections = append(i.sections, s)
+	i.mu.Unlock()
 	return i
 }
 
 func (i *ShellMode) Flush() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
 	var buf bytes.Buffer
 	for _, sec := range i.sections {
-		if sec == nil {
+		if sec == nil || sec.Data() == nil {
 			continue
 		}
 		d, err := sec.Data().Marshal(i)
@@ -97,11 +115,11 @@ func (s *ShellMode) MarshalSectionData(sdata SectionData) ([]byte, error) {
 	for _, evar := range data {
 		// if this var is an array items, add it to the array declaration
 		if len(evar.arrkey) > 0 {
-			v := fmt.Sprintf("%s_%s=%q", evar.name(), evar.arrKey(), evar.value())
-			arrs[evar.name()] = append(arrs[evar.name()], v)
+			v := fmt.Sprintf("%s_%s=%q", evar.name(s.prefix), evar.arrKey(), evar.value())
+			arrs[evar.name(s.prefix)] = append(arrs[evar.name(s.prefix)], v)
 			continue
 		}
-		nvars = append(nvars, fmt.Sprintf("%s=%q", evar.name(), evar.value()))
+		nvars = append(nvars, fmt.Sprintf("%s=%q", evar.name(s.prefix), evar.value()))
 	}
 
 	// render associate array declars first
@@ -141,13 +159,13 @@ func (e envvar) value() string {
 	return re.ReplaceAllString(e.val, "")
 }
 
-func (e envvar) name() string {
-	name := append([]string{ShellVarPrefix}, e.varname...)
+func (e envvar) name(prefix string) string {
+	name := append([]string{prefix}, e.varname...)
 	return xstrings.ToSnakeCase(strings.Join(name, " "))
 }
 
-func fmtVarName(v string) string {
-	return fmt.Sprintf("%s_%s", ShellVarPrefix, strings.ToLower(v))
+func fmtVarName(prefix, v string) string {
+	return fmt.Sprintf("%s_%s", prefix, strings.ToLower(v))
 }
 
 func (i *ShellMode) marshalSectionData(sectionData SectionData) ([]envvar, error) {
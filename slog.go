@@ -0,0 +1,238 @@
+package dsky
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	// SlogModuleKey is the attribute key holding the module of the log items
+	SlogModuleKey = "module"
+	// SlogActionKey is the attribute key holding the action of the log items
+	SlogActionKey = "action"
+	// SlogErrorKey is the attribute key holding the error logged along with the message
+	SlogErrorKey = "error"
+	// SlogHintKey is the attribute key holding the hint of the error
+	SlogHintKey = "hint"
+)
+
+// NewSlogHandler returns a slog.Handler that writes the records using the logger. The module
+// is taken from the "module" attribute, or the groups opened using WithGroup, and the action
+// from the "action" attribute. The other attributes are written as fields.
+func NewSlogHandler(logger Logger) slog.Handler {
+	return &slogHandler{logger: logger}
+}
+
+type slogHandler struct {
+	logger Logger
+	groups []string
+	attrs  []slog.Attr
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return fromSlogLevel(level) >= h.logger.Level()
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	l := h.logger
+	if len(h.groups) > 0 {
+		l = l.WithModule(strings.Join(h.groups, "."))
+	}
+	apply := func(a slog.Attr) bool {
+		l = withSlogAttr(l, "", a)
+		return true
+	}
+	for _, a := range h.attrs {
+		apply(a)
+	}
+	r.Attrs(apply)
+
+	switch fromSlogLevel(r.Level) {
+	case LogLevelDebug:
+		l.Debug(r.Message)
+	case LogLevelWarn:
+		l.Warn(r.Message)
+	case LogLevelError:
+		l.Error(r.Message)
+	default:
+		l.Info(r.Message)
+	}
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	nh := *h
+	nh.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &nh
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return h
+	}
+	nh := *h
+	nh.groups = append(append([]string{}, h.groups...), name)
+	return &nh
+}
+
+// withSlogAttr returns the logger with the attribute applied as the module, the action or a field
+func withSlogAttr(l Logger, prefix string, a slog.Attr) Logger {
+	v := a.Value.Resolve()
+	switch {
+	case a.Equal(slog.Attr{}):
+		return l
+	case v.Kind() == slog.KindGroup:
+		if len(a.Key) > 0 {
+			prefix = prefix + a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			l = withSlogAttr(l, prefix, ga)
+		}
+		return l
+	case len(prefix) == 0 && a.Key == SlogModuleKey:
+		return l.WithModule(v.String())
+	case len(prefix) == 0 && a.Key == SlogActionKey:
+		return l.WithAction(LogAction(v.String()))
+	default:
+		return l.With(prefix+a.Key, v.Any())
+	}
+}
+
+// NewSlogLogger returns a Logger that forwards the log items to the slog.Handler, with the
+// module, the action and the fields as attributes
+func NewSlogLogger(h slog.Handler) Logger {
+	return &slogLogger{handler: h, level: DefaultLogLevel()}
+}
+
+type slogLogger struct {
+	handler slog.Handler
+	module  string
+	level   LogLevel
+	fields  []field
+	LogAction
+}
+
+func (l *slogLogger) Info(msg ...interface{}) LogItem {
+	return l.writeAction(logModeTypeInfo, l.LogAction, msg)
+}
+
+func (l *slogLogger) Warn(msg ...interface{}) LogItem {
+	return l.writeAction(logModeTypeWarn, l.LogAction, msg)
+}
+
+func (l *slogLogger) Error(msg ...interface{}) LogItem {
+	return l.writeAction(logModeTypeError, l.LogAction, msg)
+}
+
+func (l *slogLogger) Debug(msg ...interface{}) LogItem {
+	return l.writeAction(logModeTypeDebug, l.LogAction, msg)
+}
+
+func (l *slogLogger) Wait(msg ...interface{}) Task {
+	return newLogTask(l.writeAction, msg)
+}
+
+// WithModule returns a copy of the logger with the module set
+func (l *slogLogger) WithModule(module string) Logger {
+	nl := *l
+	nl.module = module
+	return &nl
+}
+
+// WithAction returns a copy of the logger with the action set
+func (l *slogLogger) WithAction(action LogAction) Logger {
+	nl := *l
+	nl.LogAction = action
+	return &nl
+}
+
+// With returns a copy of the logger with the field added
+func (l *slogLogger) With(key string, value interface{}) Logger {
+	nl := *l
+	nl.fields = withField(l.fields, key, value)
+	return &nl
+}
+
+// WithFields returns a copy of the logger with the fields added
+func (l *slogLogger) WithFields(fields Fields) Logger {
+	nl := *l
+	nl.fields = withFields(l.fields, fields)
+	return &nl
+}
+
+// WithLevel returns a copy of the logger with the minimum level set
+func (l *slogLogger) WithLevel(level LogLevel) Logger {
+	nl := *l
+	nl.level = level
+	return &nl
+}
+
+func (l *slogLogger) Level() LogLevel {
+	return l.level
+}
+
+func (l *slogLogger) writeAction(level logModeType, action LogAction, msg []interface{}) LogItem {
+	msg, report := presentErrors(msg)
+	lm := &jsonLogItem{
+		Level:     level,
+		Action:    action,
+		Module:    l.module,
+		Message:   joinMsg(msg),
+		Error:     report,
+		Timestamp: time.Now().UTC(),
+	}
+	sl := toSlogLevel(levelOf(level))
+	if levelOf(level) < l.level || !l.handler.Enabled(context.Background(), sl) {
+		return lm
+	}
+	r := slog.NewRecord(lm.Timestamp, sl, lm.Message, 0)
+	if len(l.module) > 0 {
+		r.AddAttrs(slog.String(SlogModuleKey, l.module))
+	}
+	if len(action) > 0 {
+		r.AddAttrs(slog.String(SlogActionKey, string(action)))
+	}
+	if report != nil {
+		r.AddAttrs(slog.String(SlogErrorKey, report.Message))
+		if len(report.Hint) > 0 {
+			r.AddAttrs(slog.String(SlogHintKey, report.Hint))
+		}
+	}
+	if len(l.fields) > 0 {
+		lm.Fields = newJSONObject()
+	}
+	for _, f := range l.fields {
+		r.AddAttrs(slog.Any(f.key, f.value))
+		lm.Fields.set(f.key, f.value)
+	}
+	l.handler.Handle(context.Background(), r)
+	return lm
+}
+
+func toSlogLevel(l LogLevel) slog.Level {
+	switch l {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func fromSlogLevel(l slog.Level) LogLevel {
+	switch {
+	case l < slog.LevelInfo:
+		return LogLevelDebug
+	case l < slog.LevelWarn:
+		return LogLevelInfo
+	case l < slog.LevelError:
+		return LogLevelWarn
+	default:
+		return LogLevelError
+	}
+}
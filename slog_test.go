@@ -0,0 +1,38 @@
+package dsky
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(NewSlogHandler(NewShellLogger(&buf)))
+	log.Info("created", "module", "deploy", "action", "done", "id", "abc")
+	log.WithGroup("keys").Error("no key", slog.Group("found", "count", 3))
+	log.Debug("hidden")
+	want := "# done [deploy] created id=\"abc\"\n" +
+		"# error [keys] no key found.count=\"3\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	h := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	l := NewSlogLogger(h).WithModule("deploy").With("id", "abc")
+	l.WithAction(LogActionDone).Warn("created")
+	got := buf.String()
+	for _, want := range []string{"level=WARN", `msg=created`, "module=deploy", "action=done", "id=abc"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+	buf.Reset()
+	if l.Debug("hidden"); buf.Len() != 0 {
+		t.Errorf("expected debug to be filtered by the logger level, actual %q", buf.String())
+	}
+}
@@ -0,0 +1,202 @@
+package dsky
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// spinnerFrames are the frames of the spinner rendered while a task is waiting
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is the time between the frames of the spinner, set by the tests
+var spinnerInterval = 100 * time.Millisecond
+
+// progressBarWidth is the width of the progress bar rendered for tasks with progress
+const progressBarWidth = 20
+
+// clearLine moves the cursor to the beginning of the line and clears it
+const clearLine = "\r\033[K"
+
+// spinnerLines are the spinners rendered by a logger and its derived loggers
+type spinnerLines struct {
+	line string // the spinner line on the output, guarded by the mutex of the logger
+
+	mu      sync.Mutex
+	modules map[string]*spinnerTask // the last spinner started for each module
+}
+
+func newSpinnerLines() *spinnerLines {
+	return &spinnerLines{modules: make(map[string]*spinnerTask)}
+}
+
+// start starts a spinner for the module of the logger, stopping the spinner
+// started for the module before, if any, so that only the last one is redrawn
+func (s *spinnerLines) start(l *interactiveLogger, msg []interface{}) *spinnerTask {
+	if prev := s.module(l.module); prev != nil {
+		prev.halt()
+	}
+	t := newSpinnerTask(l, msg)
+	s.mu.Lock()
+	s.modules[t.module] = t
+	s.mu.Unlock()
+	return t
+}
+
+// module returns the spinner running for the module, if any
+func (s *spinnerLines) module(module string) *spinnerTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.modules[module]
+}
+
+// remove forgets the spinner unless another one was started for its module since
+func (s *spinnerLines) remove(t *spinnerTask) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.modules[t.module] == t {
+		delete(s.modules, t.module)
+	}
+}
+
+// spinnerTask is a Task that renders a spinner, or a progress bar once the progress
+// is set, on a single line that is finalized in place when the task completes
+type spinnerTask struct {
+	logger *interactiveLogger
+	module string
+	fields []field
+	msg    []interface{}
+
+	mu             sync.Mutex
+	frame          int
+	current, total int
+
+	stop     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+	once     sync.Once
+	result   LogItem
+}
+
+func newSpinnerTask(l *interactiveLogger, msg []interface{}) *spinnerTask {
+	t := &spinnerTask{
+		logger:  l,
+		module:  l.module,
+		fields:  l.fields,
+		msg:     msg,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	l.waits.start(t.module, l.now())
+	t.render()
+	go t.spin()
+	return t
+}
+
+func (t *spinnerTask) Progress(current, total int) {
+	t.mu.Lock()
+	t.current, t.total = current, total
+	t.mu.Unlock()
+	t.render()
+}
+
+func (t *spinnerTask) Done(msg ...interface{}) LogItem {
+	return t.finish(logModeTypeInfo, LogActionDone, msg)
+}
+
+func (t *spinnerTask) Fail(msg ...interface{}) LogItem {
+	return t.finish(logModeTypeError, LogActionFail, msg)
+}
+
+// halt stops redrawing the spinner and waits for the last frame to be drawn
+func (t *spinnerTask) halt() {
+	t.stopOnce.Do(func() {
+		close(t.stop)
+		<-t.stopped
+	})
+}
+
+func (t *spinnerTask) spin() {
+	defer close(t.stopped)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.mu.Lock()
+			t.frame++
+			t.mu.Unlock()
+			t.render()
+		}
+	}
+}
+
+func (t *spinnerTask) render() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	line := t.line()
+	l := t.logger
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-t.stop:
+		// a render racing with finish must not redraw the completed task
+		return
+	default:
+	}
+	l.spinners.line = line
+	fmt.Fprint(l.out, clearLine+line)
+}
+
+// line returns the wait log item with the spinner frame, or the progress bar, as a single line
+func (t *spinnerTask) line() string {
+	indicator := t.logger.palette.Accent.Sprint(spinnerFrames[t.frame%len(spinnerFrames)])
+	if t.total > 0 {
+		indicator = t.logger.palette.Accent.Sprint(progressBar(t.current, t.total, progressBarWidth))
+	}
+	lm := t.logger.newLogItem(logModeTypeInfo, LogActionWait, t.module, append([]interface{}{indicator}, t.msg...))
+	lm.fields = t.fields
+	s, _ := lm.String()
+	return strings.TrimRight(strings.SplitN(s, "\n", 2)[0], " ")
+}
+
+func (t *spinnerTask) finish(level logModeType, action LogAction, msg []interface{}) LogItem {
+	t.once.Do(func() {
+		t.halt()
+		if len(msg) == 0 {
+			msg = t.msg
+		}
+		lm := t.logger.newLogItem(level, action, t.module, msg)
+		lm.fields = t.fields
+		t.logger.stamp(lm)
+		b, _ := lm.String()
+		t.logger.spinners.remove(t)
+		t.mu.Lock()
+		t.logger.mu.Lock()
+		t.logger.spinners.line = ""
+		fmt.Fprint(t.logger.out, clearLine+b+"\n")
+		t.logger.mu.Unlock()
+		t.mu.Unlock()
+		t.result = lm
+	})
+	return t.result
+}
+
+// progressBar returns a bar with the given width followed by the percentage, i.e: [=====>    ]  50%
+func progressBar(current, total, width int) string {
+	if current > total {
+		current = total
+	}
+	if current < 0 {
+		current = 0
+	}
+	filled := width * current / total
+	bar := strings.Repeat("=", filled)
+	if filled < width {
+		bar += ">" + strings.Repeat(" ", width-filled-1)
+	}
+	return fmt.Sprintf("[%s] %3d%%", bar, 100*current/total)
+}
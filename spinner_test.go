@@ -0,0 +1,116 @@
+package dsky
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProgressBar(t *testing.T) {
+	cases := []struct {
+		current, total int
+		want           string
+	}{
+		{0, 10, "[>         ]   0%"},
+		{5, 10, "[=====>    ]  50%"},
+		{12, 10, "[==========] 100%"},
+	}
+	for _, c := range cases {
+		if got := progressBar(c.current, c.total, 10); got != c.want {
+			t.Errorf("expected %q, actual %q", c.want, got)
+		}
+	}
+}
+
+func TestLogger_WaitFallback(t *testing.T) {
+	var buf bytes.Buffer
+	task := NewShellLogger(&buf).WithModule("deploy").Wait("deploying")
+	task.Progress(1, 2)
+	task.Done()
+	want := "# wait [deploy] deploying\n# done [deploy] deploying\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+
+	buf.Reset()
+	NewInteractiveLogger(&buf).Wait("deploying").Fail("timed out")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "(wait)") || !strings.Contains(lines[1], "(fail)") {
+		t.Errorf("expected wait and fail lines, actual %q", buf.String())
+	}
+}
+
+func TestLogger_WaitActionSpinner(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewInteractiveLogger(&buf)
+	l.term.tty = true
+	// keep the first frame of the spinner
+	defer func(d time.Duration) { spinnerInterval = d }(spinnerInterval)
+	spinnerInterval = time.Hour
+	l.WithModule("deploy").WithAction(LogActionWait).Info("deploying")
+	l.Info("bids received")
+	l.WithModule("deploy").WithAction(LogActionDone).Info("deployed")
+
+	out := buf.String()
+	// the log line clears the spinner line and the spinner is redrawn below it
+	idx := strings.Index(out, clearLine+"(info)  bids received")
+	if idx < 0 || !strings.Contains(out[idx:], "\n(wait)  [deploy] "+spinnerFrames[0]+" deploying"+clearLine) {
+		t.Errorf("expected the spinner to be redrawn after the log line, actual %q", out)
+	}
+	if !strings.Contains(out, clearLine+"(done)  [deploy] deployed (") {
+		t.Errorf("expected the spinner to be finalized in place, actual %q", out)
+	}
+	if strings.Count(out, "\n") != 2 {
+		t.Errorf("expected 2 lines, actual %q", out)
+	}
+}
+
+func TestNewLogTask(t *testing.T) {
+	var buf bytes.Buffer
+	NewLogTask(NewShellLogger(&buf), "deploying").Fail()
+	want := "# wait deploying\n# fail deploying\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
+
+// lockedBuffer is a buffer the spinners can write to while the test reads it
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLogger_WaitTwice(t *testing.T) {
+	var buf lockedBuffer
+	l := NewInteractiveLogger(&buf)
+	l.term.tty = true
+	defer func(d time.Duration) { spinnerInterval = d }(spinnerInterval)
+	spinnerInterval = time.Millisecond
+
+	deploy := l.WithModule("deploy")
+	deploy.Wait("waiting for lease")
+	deploy.Wait("waiting for manifest").Done()
+	done := buf.String()
+	time.Sleep(20 * spinnerInterval)
+
+	if got := buf.String(); got != done {
+		t.Errorf("expected the first spinner to be stopped, actual %q", got[len(done):])
+	}
+	if !strings.Contains(done, clearLine+"(done)  [deploy] waiting for manifest (") || !strings.HasSuffix(done, "\n") {
+		t.Errorf("expected the done line last, actual %q", done)
+	}
+}
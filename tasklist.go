@@ -0,0 +1,222 @@
+package dsky
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TrackedTask is a Task of a TaskList whose status message can be updated
+type TrackedTask interface {
+	Task
+
+	// Update replaces the status message of the task
+	Update(msg ...interface{})
+}
+
+// TaskList tracks tasks running concurrently, each with its own status line. On a terminal,
+// the lines are redrawn in place with the spinner and the elapsed time of every task. Otherwise
+// the status changes are written as log lines in the order they happen. The lines are written
+// using the logger of the mode, only redrawn when its level is info or below, and the log lines
+// are filtered by its level.
+//
+// Tasks should be added before starting the goroutines that run them, so that Wait
+// does not return early. Nothing else should write to the output until Wait returns.
+type TaskList struct {
+	tty   bool
+	log   *interactiveLogger
+	mu    sync.Mutex
+	tasks []*listTask
+	drawn int // number of lines drawn by the last render
+	frame int
+	wg    sync.WaitGroup
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// NewTaskList returns a TaskList that renders to the mode's errout, alongside the log
+func (i *InteractiveMode) NewTaskList() *TaskList {
+	l := NewTaskList(i.errout)
+	if log, ok := i.logger.(*interactiveLogger); ok {
+		// render using the mode's theme, width, clock and level
+		l.log, l.tty = log, log.spins()
+	}
+	return l
+}
+
+// NewTaskList returns a TaskList that renders to out
+func NewTaskList(out io.Writer) *TaskList {
+	log := NewInteractiveLogger(out)
+	return &TaskList{tty: log.spins(), log: log}
+}
+
+// Add adds a waiting task for the module with the status message
+func (l *TaskList) Add(module string, msg ...interface{}) TrackedTask {
+	t := &listTask{list: l, module: module, msg: msg, started: l.log.now(), action: LogActionWait, level: logModeTypeInfo}
+	l.wg.Add(1)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tasks = append(l.tasks, t)
+	if !l.tty {
+		l.writelog(t.item(false))
+		return t
+	}
+	if l.stop == nil {
+		l.stop, l.done = make(chan struct{}), make(chan struct{})
+		go l.spin(l.stop, l.done)
+	}
+	l.render()
+	return t
+}
+
+// Wait blocks until all the tasks are complete and renders the final status of the tasks
+func (l *TaskList) Wait() {
+	l.wg.Wait()
+	l.mu.Lock()
+	stop, done := l.stop, l.done
+	l.stop = nil
+	l.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	l.mu.Lock()
+	if l.tty {
+		l.render()
+	}
+	l.mu.Unlock()
+}
+
+func (l *TaskList) spin(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			l.frame++
+			l.render()
+			l.mu.Unlock()
+		}
+	}
+}
+
+// render redraws the status lines, the caller must hold the lock
+func (l *TaskList) render() {
+	var buf strings.Builder
+	if l.drawn > 0 {
+		// move the cursor back to the first line of the list
+		buf.WriteString(fmt.Sprintf("\033[%dA", l.drawn))
+	}
+	for _, t := range l.tasks {
+		buf.WriteString(clearLine)
+		buf.WriteString(t.line(l.frame))
+		buf.WriteString("\n")
+	}
+	l.drawn = len(l.tasks)
+	l.log.write(buf.String())
+}
+
+// writelog writes the log item unless its level is below the level of the logger
+func (l *TaskList) writelog(lm *logItem) {
+	if levelOf(lm.logModeType) >= l.log.level {
+		l.log.writelog(lm)
+	}
+}
+
+// update applies fn to the task and writes the change, the log line is only written when not on a terminal
+func (l *TaskList) update(t *listTask, logLine bool, fn func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fn()
+	if l.tty {
+		l.render()
+		return
+	}
+	if logLine {
+		l.writelog(t.item(true))
+	}
+}
+
+type listTask struct {
+	list           *TaskList
+	module         string
+	msg            []interface{}
+	started        time.Time
+	elapsed        time.Duration
+	action         LogAction
+	level          logModeType
+	current, total int
+	once           sync.Once
+	result         LogItem
+}
+
+func (t *listTask) Update(msg ...interface{}) {
+	t.list.update(t, true, func() { t.msg = msg })
+}
+
+func (t *listTask) Progress(current, total int) {
+	t.list.update(t, false, func() { t.current, t.total = current, total })
+}
+
+func (t *listTask) Done(msg ...interface{}) LogItem {
+	return t.finish(logModeTypeInfo, LogActionDone, msg)
+}
+
+func (t *listTask) Fail(msg ...interface{}) LogItem {
+	return t.finish(logModeTypeError, LogActionFail, msg)
+}
+
+func (t *listTask) finish(level logModeType, action LogAction, msg []interface{}) LogItem {
+	t.once.Do(func() {
+		t.list.update(t, true, func() {
+			if len(msg) > 0 {
+				t.msg = msg
+			}
+			t.level, t.action = level, action
+			t.elapsed = t.list.log.now().Sub(t.started)
+			t.result = t.item(true)
+		})
+		t.list.wg.Done()
+	})
+	return t.result
+}
+
+// item returns the log item for the current status, with the elapsed time when withElapsed is set
+func (t *listTask) item(withElapsed bool) *logItem {
+	msg := t.msg
+	if withElapsed && t.action != LogActionWait {
+		msg = append(append([]interface{}{}, msg...), t.list.log.palette.Dim.Sprintf("(%s)", fmtElapsed(t.elapsed)))
+	}
+	return t.list.log.newLogItem(t.level, t.action, t.module, msg)
+}
+
+// line returns the status line of the task for the spinner frame
+func (t *listTask) line(frame int) string {
+	lm := t.item(true)
+	if t.action == LogActionWait {
+		indicator := spinnerFrames[frame%len(spinnerFrames)]
+		if t.total > 0 {
+			indicator = progressBar(t.current, t.total, progressBarWidth)
+		}
+		elapsed := t.list.log.palette.Dim.Sprintf("(%s)", fmtElapsed(t.list.log.now().Sub(t.started)))
+		lm.msg = append(append([]interface{}{t.list.log.palette.Accent.Sprint(indicator)}, t.msg...), elapsed)
+	}
+	s, _ := lm.String()
+	return strings.TrimRight(strings.SplitN(s, "\n", 2)[0], " ")
+}
+
+// fmtElapsed returns the duration rounded for display
+func fmtElapsed(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	default:
+		return d.Round(100 * time.Millisecond).String()
+	}
+}
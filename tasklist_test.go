@@ -0,0 +1,85 @@
+package dsky
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testClock is a clock the tests move forward
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestTaskList_NonTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	clock := &testClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m, err := NewMode(ModeTypeInteractive, WithStderr(&buf), WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := m.(*InteractiveMode).NewTaskList()
+	tasks := []TrackedTask{l.Add("west", "deploying"), l.Add("east", "deploying")}
+	clock.Add(1500 * time.Millisecond)
+	var wg sync.WaitGroup
+	for idx, task := range tasks {
+		wg.Add(1)
+		go func(idx int, task TrackedTask) {
+			defer wg.Done()
+			task.Update("provisioning")
+			if idx == 0 {
+				task.Done()
+				return
+			}
+			task.Fail("no bids")
+		}(idx, task)
+	}
+	l.Wait()
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 6 lines, actual %d: %q", len(lines), buf.String())
+	}
+	out := buf.String()
+	for _, want := range []string{"(done)  [west] provisioning (1.5s)", "(fail)  [east] no bids (1.5s)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+}
+
+func TestTaskList_Level(t *testing.T) {
+	var buf bytes.Buffer
+	clock := &testClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m, err := NewMode(ModeTypeInteractive, WithStderr(&buf), WithLogLevel(LogLevelWarn), WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := m.(*InteractiveMode).NewTaskList()
+	west, east := l.Add("west", "deploying"), l.Add("east", "deploying")
+	clock.Add(250 * time.Millisecond)
+	west.Done()
+	east.Fail("no bids")
+	l.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], "(fail)  [east] no bids (250ms)") {
+		t.Errorf("expected only the fail line, actual %q", buf.String())
+	}
+}
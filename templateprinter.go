@@ -0,0 +1,206 @@
+package dsky
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/gosuri/uitable"
+	"github.com/huandu/xstrings"
+)
+
+// TemplateMode renders the sections using a text/template. The template is executed against
+// the same records JSONMode produces, keyed by the snake cased section ids. Pane sections with a
+// single record are exposed as the record itself, so {{.deployment.deploy_id}} works as expected.
+type TemplateMode struct {
+	sections []Section
+	tmpl     *template.Template
+	common
+}
+
+// templateFuncs are the helper functions available to the templates
+var templateFuncs = template.FuncMap{
+	"join":  tmplJoin,
+	"upper": strings.ToUpper,
+	"json":  tmplJSON,
+	"table": tmplTable,
+}
+
+func NewTemplateMode(out, errout io.Writer, text string) (*TemplateMode, error) {
+	if out == nil {
+		out = os.Stdout
+	}
+	if errout == nil {
+		errout = os.Stderr
+	}
+	tmpl, err := template.New("dsky").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("dsky: invalid template: %v", err)
+	}
+	m := &TemplateMode{
+		sections: make([]Section, 0),
+		tmpl:     tmpl,
+	}
+	m.modeType = ModeTypeTemplate
+	m.out = out
+	m.errout = errout
+	m.logger = NewShellLogger(errout)
+	return m, nil
+}
+
+func (m *TemplateMode) When(mtype ModeType, fn runF) Mode {
+	if mtype == m.modeType {
+		m.mu.Lock()
+		m.runners = append(m.runners, fn)
+		m.mu.Unlock()
+	}
+	return m
+}
+
+func (i *TemplateMode) Printer() Printer {
+	return i
+}
+
+func (i *TemplateMode) NewSection(id string) Section {
+	s := NewSection(id)
+	i.mu.Lock()
+	i.sections = append(i.sections, s)
+	i.mu.Unlock()
+	return s
+}
+
+func (i *TemplateMode) WithSection(s Section) Printer {
+	i.mu.Lock()
+	i.sections = append(i.sections, s)
+	i.mu.Unlock()
+	return i
+}
+
+// Flush executes the template once against all the sections
+func (i *TemplateMode) Flush() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	doc := make(map[string]interface{})
+	for _, sec := range i.sections {
+		if sec == nil || sec.Data() == nil {
+			continue
+		}
+		if len(sec.Data().Identifier()) == 0 {
+			return ErrInvalidSectionDataID{}
+		}
+		tree, err := templateTree(sec.Data())
+		if err != nil {
+			return err
+		}
+		for k, v := range tree {
+			doc[k] = v
+		}
+	}
+	b, err := i.execute(doc)
+	if err != nil {
+		return err
+	}
+	_, err = i.out.Write(b)
+	return err
+}
+
+func (i *TemplateMode) MarshalSectionData(sectionData SectionData) ([]byte, error) {
+	tree, err := templateTree(sectionData)
+	if err != nil {
+		return nil, err
+	}
+	return i.execute(tree)
+}
+
+func (i *TemplateMode) execute(data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := i.tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+func templateTree(sectionData SectionData) (map[string]interface{}, error) {
+	d, err := sectionDataRecords(sectionData)
+	if err != nil {
+		return nil, err
+	}
+	if recs, ok := d.([]map[string]interface{}); ok && len(recs) == 1 && sectionData.Style() == SectionDataStylePane {
+		d = recs[0]
+	}
+	id := xstrings.ToSnakeCase(sectionData.Identifier())
+	res := map[string]interface{}{id: d}
+	if raw := sectionData.Tag("raw"); raw != nil {
+		res["raw"] = raw
+	}
+	return res, nil
+}
+
+// tmplJoin joins the items of a slice using sep
+func tmplJoin(sep string, v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Sprintf("%v", v)
+	}
+	items := make([]string, rv.Len())
+	for idx := 0; idx < rv.Len(); idx++ {
+		items[idx] = fmt.Sprintf("%v", rv.Index(idx).Interface())
+	}
+	return strings.Join(items, sep)
+}
+
+// tmplJSON returns the JSON encoding of v
+func tmplJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// tmplTable renders records as a table with the given columns. When no
+// columns are given, the sorted keys of the first record are used
+func tmplTable(v interface{}, cols ...string) (string, error) {
+	var recs []map[string]interface{}
+	switch item := v.(type) {
+	case []map[string]interface{}:
+		recs = item
+	case map[string]interface{}:
+		recs = []map[string]interface{}{item}
+	default:
+		return "", fmt.Errorf("dsky: table expects records, got %T", v)
+	}
+	if len(cols) == 0 && len(recs) > 0 {
+		for k := range recs[0] {
+			cols = append(cols, k)
+		}
+		sort.Strings(cols)
+	}
+	t := uitable.New()
+	headers := make([]interface{}, len(cols))
+	for idx, c := range cols {
+		headers[idx] = strings.ToUpper(c)
+	}
+	t.AddRow(headers...)
+	for _, rec := range recs {
+		row := make([]interface{}, len(cols))
+		for idx, c := range cols {
+			if val, ok := rec[c]; ok && val != nil {
+				row[idx] = val
+				continue
+			}
+			row[idx] = ""
+		}
+		t.AddRow(row...)
+	}
+	return t.String(), nil
+}
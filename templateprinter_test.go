@@ -0,0 +1,31 @@
+package dsky
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTemplateMode_Flush(t *testing.T) {
+	var buf bytes.Buffer
+	m, err := NewMode(ModeTypeTemplate, WithStdout(&buf), WithTemplate(
+		`{{.deployment.deploy_id}} {{range .groups}}{{upper .name}};{{end}} {{json .deployment}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := m.Printer()
+	p.NewSection("Deployment").NewData().AsPane().Add("DeployID", "abc")
+	p.NewSection("Groups").NewData().AsList().Add("Name", "west").Add("Name", "east")
+	if err := p.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "abc WEST;EAST; {\"deploy_id\":\"abc\"}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, actual %q", want, got)
+	}
+}
+
+func TestTemplateMode_InvalidTemplate(t *testing.T) {
+	if _, err := NewMode(ModeTypeTemplate, WithTemplate("{{.foo")); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}
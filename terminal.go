@@ -0,0 +1,132 @@
+package dsky
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/term"
+)
+
+const (
+	// NoColorEnv disables the colors when set to any value, see https://no-color.org
+	NoColorEnv = "NO_COLOR"
+	// ForceColorEnv enables the colors even when not writing to a terminal, unless set to 0 or false
+	ForceColorEnv = "FORCE_COLOR"
+)
+
+// Terminal holds the capabilities of the writer a mode renders to. The width is re-read
+// when the terminal is resized
+type Terminal struct {
+	fd    int
+	tty   bool
+	color bool
+
+	mu     sync.Mutex
+	width  int
+	fixed  int    // the width set using WithWidth, if any
+	resize uint64 // the resize generation the width was read at
+}
+
+// NewTerminal detects the capabilities of w. The colors are enabled when w is a terminal
+// unless NO_COLOR is set or TERM is dumb, and always when FORCE_COLOR is set
+func NewTerminal(w io.Writer) *Terminal {
+	t := &Terminal{fd: -1}
+	if f, ok := w.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		t.fd, t.tty = int(f.Fd()), true
+		watchResize()
+	}
+	t.color = colorEnabled(t.tty)
+	t.width = t.readWidth()
+	t.resize = atomic.LoadUint64(&resizes)
+	return t
+}
+
+// IsTTY returns true when the writer is a terminal
+func (t *Terminal) IsTTY() bool {
+	return t.tty
+}
+
+// Color returns true when the output is to be colored
+func (t *Terminal) Color() bool {
+	return t.color
+}
+
+// WithWidth sets the width, overriding the width of the terminal when positive
+func (t *Terminal) WithWidth(width int) *Terminal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fixed = width
+	return t
+}
+
+// Width returns the width set using WithWidth, the width of the terminal,
+// or DefaultLineWidth when the writer is not a terminal
+func (t *Terminal) Width() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.fixed > 0 {
+		return t.fixed
+	}
+	if gen := atomic.LoadUint64(&resizes); gen != t.resize {
+		t.width, t.resize = t.readWidth(), gen
+	}
+	return t.width
+}
+
+func (t *Terminal) readWidth() int {
+	if t.tty {
+		if w, _, err := term.GetSize(t.fd); err == nil && w > 0 {
+			return w
+		}
+	}
+	return DefaultLineWidth
+}
+
+// hasWidth returns true when the width is known, i.e: it is a terminal or the width is set
+func (t *Terminal) hasWidth() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tty || t.fixed > 0
+}
+
+// colorEnabled returns whether to color the output of a writer
+func colorEnabled(tty bool) bool {
+	if len(os.Getenv(NoColorEnv)) > 0 {
+		return false
+	}
+	switch v := strings.ToLower(os.Getenv(ForceColorEnv)); v {
+	case "":
+	case "0", "false":
+		return false
+	default:
+		return true
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return tty
+}
+
+var (
+	// resizes counts the resizes of the terminal
+	resizes    uint64
+	resizeOnce sync.Once
+)
+
+// watchResize starts counting the resizes of the terminal, once
+func watchResize() {
+	resizeOnce.Do(func() {
+		c := make(chan os.Signal, 1)
+		if !notifyResize(c) {
+			return
+		}
+		go func() {
+			for range c {
+				atomic.AddUint64(&resizes, 1)
+			}
+		}()
+	})
+}
@@ -0,0 +1,72 @@
+package dsky
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestColorEnabled(t *testing.T) {
+	cases := []struct {
+		noColor, forceColor, term string
+		tty, want                 bool
+	}{
+		{tty: true, want: true},
+		{tty: false, want: false},
+		{noColor: "1", tty: true, want: false},
+		{noColor: "1", forceColor: "1", tty: true, want: false},
+		{forceColor: "1", tty: false, want: true},
+		{forceColor: "0", tty: true, want: false},
+		{term: "dumb", tty: true, want: false},
+		{term: "dumb", forceColor: "1", want: true},
+	}
+	for _, c := range cases {
+		t.Setenv(NoColorEnv, c.noColor)
+		t.Setenv(ForceColorEnv, c.forceColor)
+		t.Setenv("TERM", c.term)
+		if got := colorEnabled(c.tty); got != c.want {
+			t.Errorf("%+v: expected %v, actual %v", c, c.want, got)
+		}
+	}
+}
+
+func TestNewTerminal_NotTTY(t *testing.T) {
+	t.Setenv(ForceColorEnv, "")
+	term := NewTerminal(&bytes.Buffer{})
+	if term.IsTTY() || term.Color() {
+		t.Error("expected a buffer not to be a terminal nor colored")
+	}
+	if term.Width() != DefaultLineWidth {
+		t.Errorf("expected width %d, actual %d", DefaultLineWidth, term.Width())
+	}
+}
+
+func TestInteractiveLogger_ForceColor(t *testing.T) {
+	t.Setenv(NoColorEnv, "")
+	t.Setenv(ForceColorEnv, "1")
+	var buf bytes.Buffer
+	NewInteractiveLogger(&buf).Info("colored")
+	if !re.MatchString(buf.String()) {
+		t.Errorf("expected colored output, actual %q", buf.String())
+	}
+
+	t.Setenv(NoColorEnv, "1")
+	buf.Reset()
+	NewInteractiveLogger(&buf).Info("plain")
+	if re.MatchString(buf.String()) {
+		t.Errorf("expected plain output, actual %q", buf.String())
+	}
+}
+
+func TestLogItem_WrapsToWidth(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewInteractiveLogger(&buf)
+	lm := l.newLogItem(logModeTypeInfo, "", "", []interface{}{strings.Repeat("word ", 20)})
+	lm.width = 40
+	s, _ := lm.String()
+	for _, line := range strings.Split(s, "\n") {
+		if w := len(strings.TrimRight(re.ReplaceAllString(line, ""), " ")); w > 40 {
+			t.Errorf("expected lines of at most 40 columns, actual %d: %q", w, line)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package dsky
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyResize relays the resizes of the terminal to c
+func notifyResize(c chan<- os.Signal) bool {
+	signal.Notify(c, syscall.SIGWINCH)
+	return true
+}
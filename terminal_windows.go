@@ -0,0 +1,10 @@
+//go:build windows
+
+package dsky
+
+import "os"
+
+// notifyResize is a no-op, the width is read once on windows
+func notifyResize(c chan<- os.Signal) bool {
+	return false
+}
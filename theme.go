@@ -0,0 +1,302 @@
+package dsky
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Theme styles the interactive output: the colors of the log items for each level and
+// action, the headings, the table borders and the separators of the key/value pairs
+type Theme struct {
+	Name string `yaml:"name"`
+
+	// Levels are the styles of the log items for each level: debug, info, warn and error
+	Levels map[string]LevelStyle `yaml:"levels"`
+
+	// Actions are the styles of the labels for each action, i.e: wait, done and fail,
+	// overriding the label style of the level
+	Actions map[string]Style `yaml:"actions"`
+
+	// Dim is the style of the details: fields, timestamps, durations and causes of errors
+	Dim Style `yaml:"dim"`
+
+	// Accent is the style of the spinners and the progress bars
+	Accent Style `yaml:"accent"`
+
+	// Headings are the styles of the titles, from H1 to H3
+	Headings []HeadingStyle `yaml:"headings"`
+
+	// TableBorder separates the columns of the tables
+	TableBorder string `yaml:"table_border"`
+
+	// KeyValueSeparator separates the keys from the values, i.e: "Name: value"
+	KeyValueSeparator string `yaml:"key_value_separator"`
+
+	// ListSeparator separates the key/value pairs rendered on a single line
+	ListSeparator string `yaml:"list_separator"`
+}
+
+// LevelStyle is the style of the log items of a level
+type LevelStyle struct {
+	Label   Style `yaml:"label"`
+	Message Style `yaml:"message"`
+}
+
+// HeadingStyle is the style of a title level
+type HeadingStyle struct {
+	// Underline is repeated under the title for its width, no underline when empty
+	Underline string `yaml:"underline"`
+	// Upper renders the title in upper case
+	Upper bool  `yaml:"upper"`
+	Color Style `yaml:"color"`
+}
+
+var (
+	// ThemeDark is for terminals with a dark background, it is the default theme
+	ThemeDark = &Theme{
+		Name: "dark",
+		Levels: map[string]LevelStyle{
+			"debug": {Label: "hi-white", Message: "hi-white"},
+			"info":  {Label: "hi-green", Message: "hi-white"},
+			"warn":  {Label: "hi-yellow", Message: "white"},
+			"error": {Label: "hi-red", Message: "white"},
+		},
+		Actions:           map[string]Style{"wait": "hi-yellow"},
+		Dim:               "faint",
+		Accent:            "hi-yellow",
+		Headings:          defaultHeadings(""),
+		TableBorder:       "\t",
+		KeyValueSeparator: ": ",
+		ListSeparator:     " | ",
+	}
+
+	// ThemeLight is for terminals with a light background
+	ThemeLight = &Theme{
+		Name: "light",
+		Levels: map[string]LevelStyle{
+			"debug": {Label: "blue", Message: "black"},
+			"info":  {Label: "green", Message: "black"},
+			"warn":  {Label: "yellow", Message: "black"},
+			"error": {Label: "red", Message: "black"},
+		},
+		Actions:           map[string]Style{"wait": "magenta"},
+		Dim:               "hi-black",
+		Accent:            "magenta",
+		Headings:          defaultHeadings(""),
+		TableBorder:       "\t",
+		KeyValueSeparator: ": ",
+		ListSeparator:     " | ",
+	}
+
+	// ThemeMonochrome renders without colors, the levels are told apart by their labels
+	ThemeMonochrome = &Theme{
+		Name:              "monochrome",
+		Headings:          defaultHeadings(""),
+		TableBorder:       "\t",
+		KeyValueSeparator: ": ",
+		ListSeparator:     " | ",
+	}
+
+	// ThemeHighContrast uses bold, high intensity colors and visible table borders
+	ThemeHighContrast = &Theme{
+		Name: "high-contrast",
+		Levels: map[string]LevelStyle{
+			"debug": {Label: "bold hi-cyan", Message: "hi-white"},
+			"info":  {Label: "bold hi-green", Message: "bold hi-white"},
+			"warn":  {Label: "bold black bg-hi-yellow", Message: "bold hi-yellow"},
+			"error": {Label: "bold hi-white bg-red", Message: "bold hi-red"},
+		},
+		Actions:           map[string]Style{"wait": "bold hi-magenta"},
+		Dim:               "hi-white",
+		Accent:            "bold hi-magenta",
+		Headings:          defaultHeadings("bold"),
+		TableBorder:       " | ",
+		KeyValueSeparator: ": ",
+		ListSeparator:     " | ",
+	}
+
+	// DefaultTheme is the theme of the modes created without WithTheme
+	DefaultTheme = ThemeDark
+)
+
+// Themes are the built-in themes by name
+var Themes = map[string]*Theme{
+	ThemeDark.Name:         ThemeDark,
+	ThemeLight.Name:        ThemeLight,
+	ThemeMonochrome.Name:   ThemeMonochrome,
+	ThemeHighContrast.Name: ThemeHighContrast,
+}
+
+func defaultHeadings(color Style) []HeadingStyle {
+	return []HeadingStyle{
+		{Underline: "=", Color: color},
+		{Underline: "-", Color: color},
+		{Upper: true, Color: color},
+	}
+}
+
+// ErrInvalidTheme is returned for an unknown theme name
+type ErrInvalidTheme struct {
+	Name string
+}
+
+func (e ErrInvalidTheme) Error() string {
+	var names []string
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("dsky: invalid theme %q, must be one of: %s", e.Name, strings.Join(names, ", "))
+}
+
+// ThemeByName returns the built-in theme with the name
+func ThemeByName(name string) (*Theme, error) {
+	t, ok := Themes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, ErrInvalidTheme{Name: name}
+	}
+	return t, nil
+}
+
+// LoadTheme returns the theme in the YAML (or JSON) file at path. The theme extends the
+// built-in theme named by the base key, the default theme when not set, and the styles
+// set in the file replace the ones of the base for the same level, action or heading,
+// one field at a time. The headings are matched by position, from H1:
+//
+//	base: dark
+//	levels:
+//	  info: {label: hi-magenta, message: white}
+//	headings:
+//	  - {underline: "#", color: bold}
+func LoadTheme(path string) (*Theme, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f themeFile
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("dsky: invalid theme file %s: %v", path, err)
+	}
+	base := DefaultTheme
+	if len(f.Base) > 0 {
+		if base, err = ThemeByName(f.Base); err != nil {
+			return nil, err
+		}
+	}
+	t := base.clone()
+	f.merge(t)
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("dsky: invalid theme file %s: %v", path, err)
+	}
+	return t, nil
+}
+
+// themeFile is a theme file, the fields that are not set are nil
+type themeFile struct {
+	Base   string  `yaml:"base"`
+	Name   *string `yaml:"name"`
+	Levels map[string]struct {
+		Label   *Style `yaml:"label"`
+		Message *Style `yaml:"message"`
+	} `yaml:"levels"`
+	Actions  map[string]Style `yaml:"actions"`
+	Dim      *Style           `yaml:"dim"`
+	Accent   *Style           `yaml:"accent"`
+	Headings []struct {
+		Underline *string `yaml:"underline"`
+		Upper     *bool   `yaml:"upper"`
+		Color     *Style  `yaml:"color"`
+	} `yaml:"headings"`
+	TableBorder       *string `yaml:"table_border"`
+	KeyValueSeparator *string `yaml:"key_value_separator"`
+	ListSeparator     *string `yaml:"list_separator"`
+}
+
+// merge sets the fields of the theme that are set in the file
+func (f *themeFile) merge(t *Theme) {
+	setString(&t.Name, f.Name)
+	for level, ls := range f.Levels {
+		dst := t.Levels[level]
+		setStyle(&dst.Label, ls.Label)
+		setStyle(&dst.Message, ls.Message)
+		t.Levels[level] = dst
+	}
+	for action, s := range f.Actions {
+		t.Actions[action] = s
+	}
+	setStyle(&t.Dim, f.Dim)
+	setStyle(&t.Accent, f.Accent)
+	for idx, h := range f.Headings {
+		if idx >= len(t.Headings) {
+			t.Headings = append(t.Headings, HeadingStyle{})
+		}
+		dst := &t.Headings[idx]
+		setString(&dst.Underline, h.Underline)
+		if h.Upper != nil {
+			dst.Upper = *h.Upper
+		}
+		setStyle(&dst.Color, h.Color)
+	}
+	setString(&t.TableBorder, f.TableBorder)
+	setString(&t.KeyValueSeparator, f.KeyValueSeparator)
+	setString(&t.ListSeparator, f.ListSeparator)
+}
+
+func setString(dst *string, v *string) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func setStyle(dst *Style, v *Style) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+// Validate returns an error when a style of the theme has an unknown attribute
+func (t *Theme) Validate() error {
+	styles := []Style{t.Dim, t.Accent}
+	for _, ls := range t.Levels {
+		styles = append(styles, ls.Label, ls.Message)
+	}
+	for _, s := range t.Actions {
+		styles = append(styles, s)
+	}
+	for _, h := range t.Headings {
+		styles = append(styles, h.Color)
+	}
+	for _, s := range styles {
+		if _, err := s.attributes(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// heading returns the style of the title level, starting at 1
+func (t *Theme) heading(level int) (HeadingStyle, bool) {
+	if level < 1 || level > len(t.Headings) {
+		return HeadingStyle{}, false
+	}
+	return t.Headings[level-1], true
+}
+
+// clone returns a deep copy of the theme
+func (t *Theme) clone() *Theme {
+	nt := *t
+	nt.Levels = make(map[string]LevelStyle, len(t.Levels))
+	for k, v := range t.Levels {
+		nt.Levels[k] = v
+	}
+	nt.Actions = make(map[string]Style, len(t.Actions))
+	for k, v := range t.Actions {
+		nt.Actions[k] = v
+	}
+	nt.Headings = append([]HeadingStyle{}, t.Headings...)
+	return &nt
+}
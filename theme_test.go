@@ -0,0 +1,103 @@
+package dsky
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	fc "github.com/fatih/color"
+)
+
+func TestStyle_Attributes(t *testing.T) {
+	got, err := Style("bold hi-green bg-hi-red").attributes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []fc.Attribute{fc.Bold, fc.FgHiGreen, fc.BgHiRed}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, actual %v", want, got)
+	}
+	if _, err := Style("green sparkly").attributes(); err == nil {
+		t.Error("expected an error for an unknown attribute")
+	}
+}
+
+func TestBuiltinThemes_Valid(t *testing.T) {
+	for name, th := range Themes {
+		if err := th.Validate(); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.yaml")
+	data := `
+base: high-contrast
+name: branded
+levels:
+  info: {label: hi-magenta}
+headings:
+  - {underline: "#"}
+table_border: " ! "
+`
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	th, err := LoadTheme(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Name != "branded" || th.TableBorder != " ! " || th.Levels["info"].Label != "hi-magenta" {
+		t.Errorf("expected the file's settings, actual %+v", th)
+	}
+	if th.Levels["info"].Message != ThemeHighContrast.Levels["info"].Message {
+		t.Errorf("expected the base message style of the level, actual %+v", th.Levels["info"])
+	}
+	wantHeadings := []HeadingStyle{{Underline: "#", Color: "bold"}, ThemeHighContrast.Headings[1], ThemeHighContrast.Headings[2]}
+	if !reflect.DeepEqual(th.Headings, wantHeadings) {
+		t.Errorf("expected %+v, actual %+v", wantHeadings, th.Headings)
+	}
+	if th.Levels["error"] != ThemeHighContrast.Levels["error"] {
+		t.Errorf("expected the base theme's settings, actual %+v", th.Levels["error"])
+	}
+	if ThemeHighContrast.Levels["info"].Label == "hi-magenta" {
+		t.Error("expected the base theme not to be modified")
+	}
+
+	if err := ioutil.WriteFile(path, []byte("dim: glowing"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTheme(path); err == nil {
+		t.Error("expected an error for an invalid style")
+	}
+}
+
+func TestInteractiveMode_WithTheme(t *testing.T) {
+	var out bytes.Buffer
+	m, err := NewMode(ModeTypeInteractive, WithStdout(&out), WithStderr(&bytes.Buffer{}), WithTheme(ThemeHighContrast))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := m.Printer()
+	p.NewSection("bids").NewData().AsList().Add("group", 1).Add("price", 9)
+	if err := p.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "1     | 9") {
+		t.Errorf("expected the theme's table border, actual %q", out.String())
+	}
+}
+
+func TestInteractiveLogger_MonochromeTheme(t *testing.T) {
+	t.Setenv(NoColorEnv, "")
+	t.Setenv(ForceColorEnv, "1")
+	var buf bytes.Buffer
+	NewInteractiveLogger(&buf).WithTheme(ThemeMonochrome).Error("plain")
+	if re.MatchString(buf.String()) {
+		t.Errorf("expected plain output, actual %q", buf.String())
+	}
+}
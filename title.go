@@ -3,10 +3,10 @@ package dsky
 import (
 	"bytes"
 	"strings"
-)
 
-// TitleUnderliner is the underline character for the title
-var TitleUnderliner = "="
+	fc "github.com/fatih/color"
+	"github.com/gosuri/uitable/util/strutil"
+)
 
 // Title is a UI component that renders a title. Title implements Component interface.
 type Title struct {
@@ -14,10 +14,13 @@ type Title struct {
 	uliner      string
 	isUnderLine bool
 	isCaps      bool
+	level       int
+	styled      bool
+	color       *fc.Color
 }
 
 func NewTitle(text string) *Title {
-	return &Title{text: text, uliner: TitleUnderliner}
+	return &Title{text: text, uliner: "="}
 }
 
 func (t *Title) WithUnderliner(u string) *Title {
@@ -27,36 +30,67 @@ func (t *Title) WithUnderliner(u string) *Title {
 }
 
 func (t *Title) H1() *Title {
+	t.level = 1
 	return t.WithUnderliner("=")
 }
 
 func (t *Title) H2() *Title {
+	t.level = 2
 	return t.WithUnderliner("-")
 }
 
 func (t *Title) H3() *Title {
+	t.level = 3
 	t.isCaps = true
 	return t
 }
 
+// WithStyle renders the title using the heading style and the color, if any,
+// instead of the defaults of the title's level
+func (t *Title) WithStyle(s HeadingStyle, color *fc.Color) *Title {
+	t.styled = true
+	t.isUnderLine = len(s.Underline) > 0
+	t.uliner = s.Underline
+	t.isCaps = s.Upper
+	t.color = color
+	return t
+}
+
+// Markdown returns the title as an ATX style markdown heading for the title's level
+func (t *Title) Markdown() string {
+	if t.level == 0 {
+		return t.text
+	}
+	return strings.Repeat("#", t.level) + " " + t.text
+}
+
 // String returns the formated string of the title
 func (t *Title) Bytes() []byte {
 	var buf bytes.Buffer
+	if !t.isUnderLine && !t.isCaps && !t.styled {
+		return nil
+	}
 
-	switch {
-	case t.isUnderLine:
-		buf.WriteString(t.text + "\n")
-		for i := 0; i < len(t.text); i++ {
-			buf.Write([]byte(t.uliner))
-		}
+	text := t.text
+	if t.isCaps {
+		text = strings.ToUpper(text)
+	}
+	buf.WriteString(t.colored(text))
+	if t.isUnderLine {
+		buf.WriteString("\n")
+		buf.WriteString(t.colored(strings.Repeat(t.uliner, strutil.StringWidth(text))))
 		buf.WriteString("\n")
-	case t.isCaps:
-		buf.WriteString(strings.ToUpper(t.text))
 	}
-
 	return buf.Bytes()
 }
 
+func (t *Title) colored(s string) string {
+	if t.color == nil {
+		return s
+	}
+	return t.color.Sprint(s)
+}
+
 func (t *Title) String() string {
 	return string(t.Bytes())
 }
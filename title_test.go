@@ -11,3 +11,19 @@ func TestTitle_String(t *testing.T) {
 		t.Fatal("==> expected:\n", expect, "==> got\n", got)
 	}
 }
+
+func TestTitle_Markdown(t *testing.T) {
+	got := NewTitle("foo").H2().Markdown()
+	expect := "## foo"
+	if got != expect {
+		t.Fatal("==> expected:\n", expect, "==> got\n", got)
+	}
+}
+
+func TestTitle_WithStyle(t *testing.T) {
+	got := NewTitle("foo").H1().WithStyle(HeadingStyle{Underline: "~", Upper: true}, nil).String()
+	expect := "FOO\n~~~\n"
+	if got != expect {
+		t.Fatal("==> expected:\n", expect, "==> got\n", got)
+	}
+}
@@ -0,0 +1,94 @@
+package dsky
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/huandu/xstrings"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type YAMLMode struct {
+	sections []Section
+	common
+}
+
+func NewYAMLMode(out, errout io.Writer) *YAMLMode {
+	if out == nil {
+		out = os.Stdout
+	}
+	if errout == nil {
+		errout = os.Stderr
+	}
+	m := &YAMLMode{
+		sections: make([]Section, 0),
+	}
+	m.modeType = ModeTypeYAML
+	m.out = out
+	m.errout = errout
+	m.logger = NewJSONLogger(errout)
+	return m
+}
+
+func (m *YAMLMode) When(mtype ModeType, fn runF) Mode {
+	if mtype == m.modeType {
+		m.mu.Lock()
+		m.runners = append(m.runners, fn)
+		m.mu.Unlock()
+	}
+	return m
+}
+
+func (i *YAMLMode) Printer() Printer {
+	return i
+}
+
+func (i *YAMLMode) NewSection(id string) Section {
+	s := NewSection(id)
+	i.mu.Lock()
+	i.sections = append(i.sections, s)
+	i.mu.Unlock()
+	return s
+}
+
+func (i *YAMLMode) WithSection(s Section) Printer {
+	i.mu.Lock()
+	i.sections = append(i.sections, s)
+	i.mu.Unlock()
+	return i
+}
+
+// Flush writes each section as a separate YAML document
+func (i *YAMLMode) Flush() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	var buf bytes.Buffer
+	for _, sec := range i.sections {
+		if sec == nil || sec.Data() == nil {
+			continue
+		}
+		b, err := sec.Data().Marshal(i)
+		if err != nil {
+			return err
+		}
+		buf.WriteString("---\n")
+		buf.Write(b)
+	}
+	_, err := fmt.Fprint(i.out, buf.String())
+	return err
+}
+
+func (i *YAMLMode) MarshalSectionData(sectionData SectionData) ([]byte, error) {
+	d, err := sectionDataRecords(sectionData)
+	if err != nil {
+		return nil, err
+	}
+	id := xstrings.ToSnakeCase(sectionData.Identifier())
+	res := map[string]interface{}{id: d}
+	if raw := sectionData.Tag("raw"); raw != nil {
+		res["raw"] = raw
+	}
+	return yaml.Marshal(res)
+}
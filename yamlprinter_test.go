@@ -0,0 +1,40 @@
+package dsky
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestYAMLMode_Flush(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewYAMLMode(&buf, nil)
+	nested := NewSectionData("").AsList().Add("Name", "west")
+	m.NewSection("Deployment").NewData().AsPane().
+		Add("DeployID", "abc").
+		Add("Groups", nested)
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := `---
+deployment:
+- deploy_id: abc
+  groups:
+  - name: west
+`
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\nactual:\n%s", want, got)
+	}
+}
+
+func TestYAMLMode_SectionWithoutData(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewYAMLMode(&buf, nil)
+	m.NewSection("empty")
+	m.NewSection("deploy").NewData().AsPane().Add("id", "abc")
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "---\ndeploy:\n- id: abc\n"; buf.String() != want {
+		t.Errorf("expected %q, actual %q", want, buf.String())
+	}
+}